@@ -1,7 +1,12 @@
 package README
 
 import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/panhongrainbow/tickerz/goTicker"
 	"github.com/stretchr/testify/require"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,3 +51,123 @@ func Benchmark_Compare_CPU(b *testing.B) {
 	fragmentedSleep := b.Run("FragmentedSleep", BenchmarkFragmentedSleep)
 	require.Equal(b, fragmentedSleep, true)
 }
+
+// tickerCount is the number of tickers created in every iteration of the engine benchmarks.
+const tickerCount = 1000
+
+// newTickers creates tickers which all fire once at the same point, and send their signals to one shared channel.
+func newTickers(b *testing.B, point time.Time) (tickers []*goTicker.GoTicker, signalChan chan tickerBase.TickerSignal) {
+	// Every ticker fires only at the point
+	opts := tickerBase.Opts{
+		BaseTime:  point.Format(tickerBase.DefaultDateTimeFormatStr),
+		Location:  tickerBase.DefaultTimeZone,
+		BaseList:  []string{point.Format(tickerBase.DefaultDateTimeFormatStr)},
+		BeginTime: point.Add(-1 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr),
+		EndTime:   point.Add(time.Hour).Format(tickerBase.DefaultDateTimeFormatStr),
+	}
+
+	// Create the tickers with one shared channel, so one reader can receive every signal
+	signalChan = make(chan tickerBase.TickerSignal, 4*tickerCount)
+	for i := 0; i < tickerCount; i++ {
+		gt, err := goTicker.New(opts, tickerBase.OffOpts{})
+		require.NoError(b, err)
+		gt.SignalChan = signalChan
+		tickers = append(tickers, gt)
+	}
+
+	// Return the tickers and signalChan values
+	return
+}
+
+// receivePoints receives the signal of every ticker, and returns the average latency from the point to its arrival.
+func receivePoints(signalChan chan tickerBase.TickerSignal, point time.Time) (latency time.Duration) {
+	for received := 0; received < tickerCount; {
+		signal := <-signalChan
+		if signal.SignalStatus == tickerBase.SignalOnTime || signal.SignalStatus == tickerBase.SignalDelay {
+			latency += time.Since(point)
+			received++
+		}
+	}
+	latency /= tickerCount
+
+	// Return the latency value
+	return
+}
+
+// BenchmarkPerTicker benchmarks the current design, where every ticker runs its own SendSignals goroutine with its own timers.
+func BenchmarkPerTicker(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Create the tickers, which fire at the start of the second after the next one
+		point := time.Now().Truncate(time.Second).Add(2 * time.Second)
+		b.StopTimer()
+		tickers, signalChan := newTickers(b, point)
+		b.StartTimer()
+		goroutines := runtime.NumGoroutine()
+
+		// Start a goroutine for every ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		for j := 0; j < len(tickers); j++ {
+			wg.Add(1)
+			go func(gt *goTicker.GoTicker) {
+				defer wg.Done()
+				_ = gt.SendSignals(ctx)
+			}(tickers[j])
+		}
+		b.ReportMetric(float64(runtime.NumGoroutine()-goroutines), "goroutines")
+
+		// Receive the points
+		b.ReportMetric(float64(receivePoints(signalChan, point).Microseconds()), "us-latency")
+
+		// Stop the tickers
+		cancel()
+		wg.Wait()
+	}
+}
+
+// BenchmarkEngine benchmarks the shared engine, where one goroutine with one timer fires the points of every ticker.
+func BenchmarkEngine(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Create the tickers, which fire at the start of the second after the next one
+		point := time.Now().Truncate(time.Second).Add(2 * time.Second)
+		b.StopTimer()
+		tickers, signalChan := newTickers(b, point)
+		b.StartTimer()
+		goroutines := runtime.NumGoroutine()
+
+		// Attach every ticker to one engine
+		engine := goTicker.NewEngine()
+		for j := 0; j < len(tickers); j++ {
+			require.NoError(b, engine.Attach(tickers[j]))
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			_ = engine.Run(ctx)
+			close(done)
+		}()
+		b.ReportMetric(float64(runtime.NumGoroutine()-goroutines), "goroutines")
+
+		// Receive the points
+		b.ReportMetric(float64(receivePoints(signalChan, point).Microseconds()), "us-latency")
+
+		// Stop the engine
+		cancel()
+		<-done
+	}
+}
+
+// Benchmark_Compare_Engine compares the goroutines, allocations and firing latency of the per-ticker design and the shared engine.
+func Benchmark_Compare_Engine(b *testing.B) {
+	// Run the benchmark for a goroutine per ticker
+	perTicker := b.Run("PerTicker", BenchmarkPerTicker)
+	require.Equal(b, perTicker, true)
+
+	// Run the benchmark for the shared engine
+	engine := b.Run("Engine", BenchmarkEngine)
+	require.Equal(b, engine, true)
+}
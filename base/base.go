@@ -1,8 +1,7 @@
 package base
 
 import (
-	"errors"
-	"log"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -49,11 +48,50 @@ const (
 	ErrInactiveBaseListAndRepeatList = Error("inactive base list and repeat list")
 	ErrUserInterrupted               = Error("user interrupt")
 	ErrAlreadyActive                 = Error("already active")
+	ErrNegativeBufferSize            = Error("negative buffer size")
+	ErrUnSupportedOverflowPolicy     = Error("unsupported overflow policy")
+	ErrOverflowPolicyWithoutBuffer   = Error("overflow policy without buffer")
+	ErrUnknownSubscription           = Error("unknown subscription")
+	ErrBufferSizeChanged             = Error("buffer size differs from the signal channel")
+	ErrNoWindow                      = Error("no window")
+	ErrNotAttached                   = Error("not attached")
+	ErrNegativePrecision             = Error("negative precision")
+	ErrUnSupportedMode               = Error("unsupported mode")
+	ErrModeWithoutDuration           = Error("computer mode without duration")
+	ErrNotMockedTicker               = Error("not mocked ticker")
+	ErrUnSupportedTimeLayout         = Error("unsupported time layout")
+	ErrDefaultLocationFallback       = Error("default location falls back to UTC")
+	ErrUnSupportedOverlapPolicy      = Error("unsupported overlap policy")
+	ErrNegativeOverlapTolerance      = Error("negative overlap tolerance")
+	ErrUnSupportedAllDayTime         = Error("unsupported all-day time")
 )
 
+// scheduling modes
 const (
-	HumanMode uint = iota + 1
-	CommputerMode
+	HumanMode     uint = iota + 1 // fire at the clock times of the location, following time zone changes and local midnight
+	CommputerMode                 // fire every Duration of elapsed time from the start, ignoring changes of the clock
+)
+
+// overflow policies of a buffered signal channel
+const (
+	OverflowBlock      uint = iota + 1 // wait until there is room in the buffer
+	OverflowDropNewest                 // drop the signal which does not fit
+	OverflowDropOldest                 // drop the oldest signal in the buffer to make room
+	OverflowCoalesce                   // merge the oldest signal in the buffer into the new one
+)
+
+// overlap policies for BaseList elements on a repeated point, or within OverlapTolerance of one
+const (
+	OverlapMerge    uint = iota + 1 // fire once, at the repeated point
+	OverlapError                    // reject the options with ErrBaseListOverlaps
+	OverlapFireBoth                 // fire for the repeated point and for the element, with distinct sources
+)
+
+// sources of a point
+const (
+	SourceRepeat   uint = iota + 1 // a repeated point, BaseTime + k*Duration
+	SourceBaseList                 // an element of BaseList
+	SourceMerged                   // a repeated point with BaseList elements merged into it
 )
 
 const (
@@ -62,6 +100,9 @@ const (
 	SignalUserInterrupt
 	SignalWaitForTomorrow
 	SignalAbandonPrevious
+	SignalManual
+	SignalOptsUpdated
+	SignalClockJumped
 )
 
 type Error string
@@ -87,11 +128,72 @@ type Base struct {
 	Active32       uint32
 	Status         atomic.Uint32
 	SignalChan     chan TickerSignal
+	TriggerChan    chan struct{}   // manual trigger requests for SendSignals
+	ReloadChan     chan struct{}   // option reload notifications for SendSignals
+	SendTimeout    time.Duration   // drop a signal nobody receives within this time, 0 waits forever
+	DroppedSignals atomic.Uint64   // number of signals dropped by SendTimeout
+	OverflowCount  atomic.Uint64   // number of signals dropped or merged by the overflow policy
+	Subscriptions  []*Subscription // extra receivers of every signal
+	PollStamp      int64           // Unix time of the last poll by Due
+	MockNano       atomic.Int64    // Unix nanoseconds of the mocked current time, 0 when the time is not mocked
+	MockChan       chan struct{}   // notifies SendSignals that the mocked time has moved
+	MockWait       atomic.Int64    // Unix nanoseconds of the mocked time SendSignals is waiting for, 0 when it is not waiting
+	MockWaitChan   chan int64      // set while Simulate runs, which receives MockWait every time SendSignals starts waiting
 	SerialBase     uint64
 	SerialHandler  func(serialBase *uint64, timeStamp int64) (serialNumber uint64)
+	WallClock      func() time.Time                      // reads the wall clock for the clock jump check, nil reads the system clock
+	EngineRequest  func(request uint)                    // set while an engine drives the ticker, which takes its trigger and reload requests
+	SignalRecorder func(signal TickerSignal) (emit bool) // set while Simulate runs, which records every signal and tells whether to send it on
 	Mu             sync.Mutex
 }
 
+// Snapshot is an immutable copy of the state of a ticker at one moment.
+type Snapshot struct {
+	NowDate        string
+	BaseLocation   *time.Location
+	BaseStamp      int64
+	BaseStampType  uint // time type
+	BaseList       []int64
+	BaseListType   uint // time type
+	BeginStamp     int64
+	BeginStampType uint // time type
+	EndStamp       int64
+	EndStampType   uint // time type
+	Mode           uint
+	Opts           Opts
+	OffOpts        OffOpts
+	Active         bool
+	Status         uint32
+	SerialBase     uint64
+	PollStamp      int64
+	Upcoming       []int64 // upcoming points in the wait list
+}
+
+// SimulationOpts configures a run of a ticker on a virtual clock.
+type SimulationOpts struct {
+	Speed float64 // virtual time passing per unit of real time, 0 runs as fast as possible
+	Emit  bool    // also send the signals to the channel and the subscriptions of the ticker
+}
+
+// SimulationReport lists what a ticker did during a run on a virtual clock.
+type SimulationReport struct {
+	From      time.Time
+	To        time.Time
+	Signals   []TickerSignal // every signal in the order it was sent
+	Firings   int            // number of on-time and delay signals
+	Rollovers int            // number of times the ticker moved on to the next date
+}
+
+// Subscription is an independent channel which receives every signal of a ticker,
+// with its own buffer and overflow policy.
+type Subscription struct {
+	SignalChan     chan TickerSignal
+	OverflowPolicy uint
+	OverflowCount  atomic.Uint64 // number of signals dropped or merged by the overflow policy
+	DroppedSignals atomic.Uint64 // number of signals dropped by the send timeout of the ticker
+	DoneChan       chan struct{} // closed when the subscription is cancelled
+}
+
 type Opts struct {
 	BaseTime  string // dateTime or time
 	Location  string
@@ -99,6 +201,23 @@ type Opts struct {
 	BaseList  []string
 	BeginTime string
 	EndTime   string
+	// BufferSize is the capacity of the signal channel created by New
+	BufferSize int
+	// OverflowPolicy decides what happens to a signal when the buffer is full, 0 means OverflowBlock
+	OverflowPolicy uint
+	// Precision turns on the precision mode of SendSignals when it is positive:
+	// the ticker sleeps until shortly before every point, sleeps in shorter steps until it is within Precision,
+	// and spins for the rest, so the signal is sent as close to the point as possible
+	Precision time.Duration
+	// Mode selects HumanMode or CommputerMode, 0 means HumanMode.
+	// CommputerMode only uses Duration, because BaseList and the window are clock times
+	Mode uint
+	// OverlapPolicy decides what happens to a BaseList element on a repeated point, 0 means OverlapMerge
+	OverlapPolicy uint
+	// OverlapTolerance makes an element within this distance of a repeated point overlap it as well
+	OverlapTolerance time.Duration
+	// AllDayTime is the time of day at which a date in BaseList fires, such as "9:0:0", empty means the start of the date
+	AllDayTime string
 }
 
 type OffOpts struct {
@@ -112,25 +231,50 @@ type OffOpts struct {
 	SundayOff    bool
 }
 
-type TickerSignal struct {
-	SignalStatus uint
-	SerialNumber uint64
-	DelaySeconds int64
-}
+// IsOff reports whether the ticker is off on the given day of the week.
+func (receive OffOpts) IsOff(weekday time.Weekday) bool {
+	// Every day is off
+	if receive.EveryDayOff {
+		return true
+	}
 
-func init() {
-	var err error
-	defaultTimeLocation, err = time.LoadLocation("Asia/Shanghai")
-	if err != nil {
-		log.Fatal(errors.New("default timeLocation error"))
+	// Check the flag of the day
+	switch weekday {
+	case time.Monday:
+		return receive.MondayOff
+	case time.Tuesday:
+		return receive.TuesdayOff
+	case time.Wednesday:
+		return receive.WednesdayOff
+	case time.Thursday:
+		return receive.ThursdayOff
+	case time.Friday:
+		return receive.FridayOff
+	case time.Saturday:
+		return receive.SaturdayOff
+	case time.Sunday:
+		return receive.SundayOff
 	}
+
+	// Return false for an unknown day
+	return false
 }
 
-var defaultTimeLocation *time.Location
+type TickerSignal struct {
+	SignalStatus     uint
+	SerialNumber     uint64
+	TimeStamp        int64 // Unix time of the point the signal is sent for
+	DelaySeconds     int64
+	CoalescedSignals uint64        // number of earlier signals merged into this one by OverflowCoalesce
+	Drift            time.Duration // how late the on-time signal was sent after its point
+	JumpSize         time.Duration // how far the wall clock jumped against the monotonic clock, for SignalClockJumped
+	Source           uint          // where the point comes from, 0 for signals without a point
+}
 
 /*
 TimeType determines the type of time format based on the string str,
 and returns the format code tType and error err.
+A zone suffix such as " [Asia/Tokyo]" does not change the type, but it must be a supported location.
 */
 func TimeType(str string) (tType uint, err error) {
 	// If the str string is empty, set the tType format code to EmptyTimeFormat and return
@@ -138,28 +282,17 @@ func TimeType(str string) (tType uint, err error) {
 		tType = EmptyTimeFormat
 		return
 	}
-	/*
-		If the str string matches the default date format,
-		set the tType format code to DateFormat and return
-	*/
-	if _, err = time.Parse(DefaultDateFormatStr, str); err == nil {
-		tType = DateFormat
-		return
-	}
-	/*
-		If the str string matches the default time format,
-		set the tType format code to TimeFormat and return
-	*/
-	if _, err = time.Parse(DefaultTimeFormatStr, str); err == nil {
-		tType = TimeFormat
+	// Split the zone off the str string
+	str, _, err = SplitZone(str)
+	if err != nil {
 		return
 	}
 	/*
-		If the str string matches the default date-time format,
-		set the tType format code to DatetimeFormat and return
+		If the str string matches a format in the registry,
+		set the tType format code to the type of the format and return
 	*/
-	if _, err = time.Parse(DefaultDateTimeFormatStr, str); err == nil {
-		tType = DatetimeFormat
+	var ok bool
+	if _, tType, _, ok = matchTime(str); ok {
 		return
 	}
 	/*
@@ -173,18 +306,31 @@ func TimeType(str string) (tType uint, err error) {
 /*
 TimeValue parses the dateTimeStr string dateTimeStr as a date-time in the specified location,
 and returns its Unix timestamp in seconds.
+Any date-time of the format registry is accepted, and a date-time with an offset (like RFC 3339) keeps its offset instead of the location.
+A zone suffix such as " [Asia/Tokyo]" takes the place of the location.
 */
 func TimeValue(dateTimeStr string, location *time.Location) (timeStamp int64, err error) {
-	// If no location is specified, use the default time zone
-	if location == nil {
-		location = defaultTimeLocation
+	// Use the zone of the dateTimeStr string if it has one
+	var zone *time.Location
+	dateTimeStr, zone, err = SplitZone(dateTimeStr)
+	if err != nil {
+		return
+	}
+	// If no location is specified, use the default location, which may fall back to UTC with an error
+	var fallbackErr error
+	if zone != nil {
+		location = zone
+	} else if location == nil {
+		location, fallbackErr = DefaultLocation()
 	}
 	// Parse the dateTimeStr string dateTimeStr as a date-time in the specified location
 	var tmp time.Time
-	tmp, err = time.ParseInLocation(DefaultDateTimeFormatStr, dateTimeStr, location)
+	tmp, err = dateTimeValue(dateTimeStr, location)
 	// If an error occurs during parsing, set the error to ErrTimeParsion
 	if err != nil {
 		err = ErrTimeParsion
+	} else {
+		err = fallbackErr
 	}
 	// Set the timeStamp value to the Unix timestamp in seconds
 	timeStamp = tmp.Unix()
@@ -192,37 +338,83 @@ func TimeValue(dateTimeStr string, location *time.Location) (timeStamp int64, er
 	return
 }
 
-// CheckOpts function is responsible for validating the received Opts structure.
+/*
+CheckOpts function is responsible for validating the received Opts structure.
+It checks every field rather than stopping at the first problem, and returns a *ValidationError listing all of them,
+which errors.Is matches against the Err constants of the reasons.
+*/
 func (receive *Opts) CheckOpts() (err error) {
+	problems := &ValidationError{}
+
 	// Validate the format of the base time
-	var tp uint
-	tp, err = TimeType(receive.BaseTime)
-	// If the base time format is not supported, return an error
-	if err == ErrUnSupportedTimeFormat {
-		return
-	}
-	// Check if the time type is either TimeFormat or DatetimeFormat
-	if tp != TimeFormat && tp != DatetimeFormat {
-		err = ErrUnsupBasetime
-		return
+	tp, typeErr := TimeType(receive.BaseTime)
+	if typeErr != nil {
+		// The base time format or its zone is not supported
+		problems.add("BaseTime", -1, receive.BaseTime, typeErr)
+	} else if tp != TimeFormat && tp != DatetimeFormat {
+		// Check if the time type is either TimeFormat or DatetimeFormat
+		problems.add("BaseTime", -1, receive.BaseTime, ErrUnsupBasetime)
 	}
 
-	// If the location is empty, set it to the default time zone
+	// If the location is empty, use the default location, which is left empty when it falls back to UTC
+	var location *time.Location
 	if receive.Location == "" {
-		receive.Location = DefaultTimeZone
-	}
-	// Load the location to validate it
-	_, err = time.LoadLocation(receive.Location)
-	// if the location is not supported, return an error
-	if err != nil {
-		err = ErrUnSupportedLocation
-		return
+		var fallbackErr error
+		location, fallbackErr = DefaultLocation()
+		if fallbackErr == nil {
+			receive.Location = DefaultLocationName()
+		}
+	} else {
+		// Load the location to validate it, and check the other times in UTC if it is not supported
+		var locationErr error
+		location, locationErr = LoadLocation(receive.Location)
+		if locationErr != nil {
+			problems.add("Location", -1, receive.Location, ErrUnSupportedLocation)
+			location = time.UTC
+		}
 	}
 
 	// Validate that the duration is not negative
 	if receive.Duration.Nanoseconds() < 0 {
-		err = ErrNegativeDuration
-		return
+		problems.add("Duration", -1, receive.Duration.String(), ErrNegativeDuration)
+	}
+
+	// Validate the mode, the computer mode needs a duration to repeat
+	switch receive.Mode {
+	case 0, HumanMode:
+	case CommputerMode:
+		if receive.Duration <= 0 {
+			problems.add("Mode", -1, strconv.FormatUint(uint64(receive.Mode), 10), ErrModeWithoutDuration)
+		}
+	default:
+		problems.add("Mode", -1, strconv.FormatUint(uint64(receive.Mode), 10), ErrUnSupportedMode)
+	}
+
+	// Validate that the precision is not negative
+	if receive.Precision < 0 {
+		problems.add("Precision", -1, receive.Precision.String(), ErrNegativePrecision)
+	}
+
+	// Validate the buffer of the signal channel
+	if bufferErr := CheckBuffer(receive.BufferSize, receive.OverflowPolicy); bufferErr == ErrNegativeBufferSize {
+		problems.add("BufferSize", -1, strconv.Itoa(receive.BufferSize), bufferErr)
+	} else if bufferErr != nil {
+		problems.add("OverflowPolicy", -1, strconv.FormatUint(uint64(receive.OverflowPolicy), 10), bufferErr)
+	}
+
+	// Validate the overlap policy and its tolerance
+	if receive.OverlapPolicy > OverlapFireBoth {
+		problems.add("OverlapPolicy", -1, strconv.FormatUint(uint64(receive.OverlapPolicy), 10), ErrUnSupportedOverlapPolicy)
+	}
+	if receive.OverlapTolerance < 0 {
+		problems.add("OverlapTolerance", -1, receive.OverlapTolerance.String(), ErrNegativeOverlapTolerance)
+	}
+
+	// Validate the time of day of the dates in the baseList, which must be a time if it is set
+	if receive.AllDayTime != "" {
+		if allDayType, allDayErr := TimeType(receive.AllDayTime); allDayErr != nil || allDayType != TimeFormat {
+			problems.add("AllDayTime", -1, receive.AllDayTime, ErrUnSupportedAllDayTime)
+		}
 	}
 
 	// Set the current time
@@ -231,54 +423,64 @@ func (receive *Opts) CheckOpts() (err error) {
 	date := now.Format("2006-1-2")
 
 	// Check the validity of the baseList
-	err = checkOptsBaseList(receive.BaseList, date)
-	if err != nil {
-		return
+	problems.merge(checkOptsBaseList(receive.BaseList, date, receive.AllDayTime))
+
+	// Under OverlapError, check that no element of the baseList overlaps a repeated point
+	if receive.OverlapPolicy == OverlapError {
+		problems.merge(receive.checkOptsOverlaps(date, location))
 	}
 
-	// Declare variables for begin and end time types
-	var beginTp, endTp uint
-	// Declare variables for begin and end times
-	var beginTime, endTime time.Time
-	// Check the validity of the beginTime and convert it to a time.Time object
-	beginTp, beginTime, err = checkOptsBeginEndTimeToTime(receive.BeginTime, date)
-	if err != nil {
-		return
+	// Check the validity of the begin and end times and convert them to time.Time objects
+	beginTp, beginTime, beginErr := checkOptsBeginEndTimeToTimeIn(receive.BeginTime, date, location)
+	if beginErr != nil {
+		problems.add("BeginTime", -1, receive.BeginTime, beginErr)
 	}
-	// Check the validity of the endTime and convert it to a time.Time object
-	endTp, endTime, err = checkOptsBeginEndTimeToTime(receive.EndTime, date)
-	if err != nil {
-		return
+	endTp, endTime, endErr := checkOptsBeginEndTimeToTimeIn(receive.EndTime, date, location)
+	if endErr != nil {
+		problems.add("EndTime", -1, receive.EndTime, endErr)
 	}
 
-	// If either the begin time or the end time is empty, return immediately
-	if (beginTp == EmptyTimeFormat && endTp == DatetimeFormat) ||
-		(beginTp == DatetimeFormat && endTp == EmptyTimeFormat) {
+	// Compare the begin time and end time if both are valid, where either of them may be empty with a date-time
+	switch {
+	case beginErr != nil || endErr != nil:
+	case (beginTp == EmptyTimeFormat && endTp == DatetimeFormat) ||
+		(beginTp == DatetimeFormat && endTp == EmptyTimeFormat):
+	case beginTp != endTp:
+		// The time types of begin time and end time are not equal
+		problems.add("EndTime", -1, receive.EndTime, ErrBeginEndTimeTypeNotEqual)
+	case receive.BeginTime != "" && receive.EndTime != "" && !beginTime.Before(endTime):
+		// The begin time is after or equal to the end time
+		problems.add("EndTime", -1, receive.EndTime, ErrIncorrectBeginEndTimeOrder)
+	}
+
+	// Return err value, which is nil if no problem has been found
+	return problems.orNil()
+}
+
+/*
+CheckBuffer checks the buffer size and the overflow policy of a signal channel.
+A policy other than OverflowBlock only makes sense when there is a buffer to overflow.
+*/
+func CheckBuffer(bufferSize int, overflowPolicy uint) (err error) {
+	// Validate that the buffer size is not negative
+	if bufferSize < 0 {
+		err = ErrNegativeBufferSize
 		return
 	}
 
-	// If the time types of begin time and end time are not equal, return an error
-	if beginTp != endTp {
-		// Assign the value of ErrBeginEndTimeTypeNotEqual to err and return
-		err = ErrBeginEndTimeTypeNotEqual
+	// Check if the overflow policy is supported
+	if overflowPolicy > OverflowCoalesce {
+		err = ErrUnSupportedOverflowPolicy
 		return
 	}
 
-	// Check if both the begin time and end time have values
-	if receive.BeginTime != "" && receive.EndTime != "" {
-		// Compare the begin time and end time to check if they are in the correct order
-		if !beginTime.Before(endTime) {
-			// If the begin time is after or equal to the end time, return an error
-			err = ErrIncorrectBeginEndTimeOrder
-			return
-		}
+	// Check if the overflow policy needs a buffer
+	if overflowPolicy > OverflowBlock && bufferSize == 0 {
+		err = ErrOverflowPolicyWithoutBuffer
+		return
 	}
 
-	/*
-		If the function has reached this point,
-		it means the begin and end times are valid and in the correct order,
-		so return without errors
-	*/
+	// Return err value
 	return
 }
 
@@ -286,74 +488,183 @@ func (receive *Opts) CheckOpts() (err error) {
 checkOptsBaseList checks baseList validity.
 It validates each base time format and checks if the time type is TimeFormat or DatetimeFormat.
 It also checks if all elements in baseList have the same time format.
+Elements with their own zones are not checked for the order, because it depends on the date, and the ticker sorts them.
 */
-func checkOptsBaseList(baseList []string, date string) (err error) {
-	var tp uint
+func checkOptsBaseList(baseList []string, date string, allDayTime string) (err error) {
+	problems := &ValidationError{}
 	// Initialize the previous time to zero
 	var previous time.Time
 	// Initialize the previous time type to zero
 	var previousType uint
-	// Loop through the base list
 
+	// Loop through the base list, recording the problems of every element
 	for i := 0; i < len(baseList); i++ {
 		// Validate the format of each base time
-		tp, err = TimeType(baseList[i])
-		// If the format is not supported, return an error
-		if err != nil {
-			return
+		tp, typeErr := TimeType(baseList[i])
+		// If the format is not supported, go on with the next element
+		if typeErr != nil {
+			problems.add("BaseList", i, baseList[i], typeErr)
+			continue
 		}
-		// Check if the time type is either TimeFormat or DatetimeFormat
-		if tp != TimeFormat && tp != DatetimeFormat {
-			err = ErrUnSupportedBaseList
-			return
+		// Check if the time type is either TimeFormat, DateFormat or DatetimeFormat
+		if tp != TimeFormat && tp != DateFormat && tp != DatetimeFormat {
+			problems.add("BaseList", i, baseList[i], ErrUnSupportedBaseList)
+			continue
 		}
 
-		// Set the datetime string based on the time type
-		var datetime string
-		if tp == TimeFormat {
-			datetime = date + " " + baseList[i]
-		}
-		if tp == DatetimeFormat {
-			datetime = baseList[i]
+		// Check if the previous time type matches the current time type, where dates and date-times may be mixed
+		if tp == DateFormat {
+			tp = DatetimeFormat
 		}
-
-		// Check if the previous time type matches the current time type
 		if previousType == 0 {
 			previousType = tp
-		} else if previousType != 0 &&
-			previousType != tp {
-			err = ErrBaseListDifferentTypes
-			return
+		} else if previousType != tp {
+			problems.add("BaseList", i, baseList[i], ErrBaseListDifferentTypes)
+			continue
 		}
 
-		// Parse the datetime string into a time.Time object
-		var tmp time.Time
-		tmp, err = time.Parse(DefaultDateTimeFormatStr, datetime)
-		if err != nil {
-			return
+		// Convert the element into its time on the date
+		stamp, valueErr := baseListValue(baseList[i], date, allDayTime, time.UTC)
+		if valueErr != nil {
+			problems.add("BaseList", i, baseList[i], valueErr)
+			continue
 		}
-		// Check if the current time is not before the previous time
+
+		// An element with its own zone takes no part in the order
+		if _, zone, _ := SplitZone(baseList[i]); zone != nil {
+			continue
+		}
+
+		// Check if the current time is after the previous time
+		tmp := time.Unix(stamp, 0)
 		if !previous.Before(tmp) {
-			err = ErrIncorrectBaseListOrder
-			return
-		} else if previous.Before(tmp) {
-			previous = tmp
+			problems.add("BaseList", i, baseList[i], ErrIncorrectBaseListOrder)
 		}
+		previous = tmp
 	}
 
 	/*
-		If the function has reached this point,
+		If no problem has been found,
 		it means the base list is valid and in the correct order,
 		so return without errors
 	*/
+	return problems.orNil()
+}
+
+/*
+baseListValue converts an element of BaseList into its Unix time in the location,
+where a time is put on the date and a date fires at allDayTime.
+*/
+func baseListValue(str string, date string, allDayTime string, location *time.Location) (stamp int64, err error) {
+	// Find the time type of the element
+	var tp uint
+	tp, err = TimeType(str)
+	if err != nil {
+		return
+	}
+
+	// Convert the element by its type
+	switch tp {
+	case TimeFormat:
+		stamp, err = TimeOnDate(date, str, location)
+	case DateFormat:
+		stamp, err = DateAtTime(str, allDayTime, location)
+	default:
+		stamp, err = TimeValue(str, location)
+	}
+
+	// Return the stamp and err values
 	return
 }
 
+/*
+checkOptsOverlaps checks that no element of BaseList is on a repeated point of BaseTime + k*Duration,
+or within OverlapTolerance of one, on any date in the location.
+A clock time as BaseTime starts the repeated points again on every date, so the phase is the same on each date,
+and an element with a date is checked against the repeated points of its own date.
+A fixed date and time as BaseTime keeps repeating across dates, so a clock time as an element moves against
+the repeated points by a day on every date, and it overlaps on some date if it is near a multiple of
+the greatest common divisor of the duration and a day.
+Elements or a base time which can not be converted are left to the other checks.
+*/
+func (receive *Opts) checkOptsOverlaps(date string, location *time.Location) (err error) {
+	problems := &ValidationError{}
+
+	// Only a duration of a second or more repeats
+	duration := int64(receive.Duration.Seconds())
+	if duration < 1 {
+		return
+	}
+
+	// Convert the base time on the date
+	tp, typeErr := TimeType(receive.BaseTime)
+	if typeErr != nil {
+		return
+	}
+	var baseStamp int64
+	var valueErr error
+	if tp == TimeFormat {
+		baseStamp, valueErr = TimeOnDate(date, receive.BaseTime, location)
+	} else {
+		baseStamp, valueErr = TimeValue(receive.BaseTime, location)
+	}
+	if valueErr != nil {
+		return
+	}
+
+	// Measure the distance of every element to its nearest repeated point over the phases it meets
+	tolerance := int64(receive.OverlapTolerance / time.Second)
+	for i := 0; i < len(receive.BaseList); i++ {
+		stamp, elementErr := baseListValue(receive.BaseList[i], date, receive.AllDayTime, location)
+		if elementErr != nil {
+			continue
+		}
+		elementType, _ := TimeType(receive.BaseList[i])
+		anchor, period := baseStamp, duration
+		switch {
+		case tp == TimeFormat && elementType != TimeFormat:
+			// The repeated points start again on the date of the element
+			anchor, elementErr = TimeOnDate(time.Unix(stamp, 0).In(location).Format(DefaultDateFormatStr), receive.BaseTime, location)
+			if elementErr != nil {
+				continue
+			}
+		case tp != TimeFormat && elementType == TimeFormat:
+			// The element moves against the repeated points by a day on every date
+			period = gcd(duration, 24*60*60)
+		}
+		offset := (stamp - anchor) % period
+		if offset < 0 {
+			offset += period
+		}
+		if offset <= tolerance || period-offset <= tolerance {
+			problems.add("BaseList", i, receive.BaseList[i], ErrBaseListOverlaps)
+		}
+	}
+
+	// Return err value
+	return problems.orNil()
+}
+
+// gcd returns the greatest common divisor of two positive numbers.
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 /*
 checkOptsBeginEndTimeToTime validates a begin or end time string, converts it into a time.Time object, and returns the time type and an error if there is any.
 It supports specific time formats and returns an error if the format is unsupported.
 */
 func checkOptsBeginEndTimeToTime(beginEndTimeStr string, date string) (beginEndTimeType uint, beginEndTime time.Time, err error) {
+	// Return the beginEndTimeType, beginEndTime and err values
+	return checkOptsBeginEndTimeToTimeIn(beginEndTimeStr, date, time.UTC)
+}
+
+// checkOptsBeginEndTimeToTimeIn is checkOptsBeginEndTimeToTime in the location of the options,
+// which decides the date of a begin or end time with its own zone.
+func checkOptsBeginEndTimeToTimeIn(beginEndTimeStr string, date string, location *time.Location) (beginEndTimeType uint, beginEndTime time.Time, err error) {
 	// Validate the format of the begin or end time string and convert it to time type
 	beginEndTimeType, err = TimeType(beginEndTimeStr)
 	// If the format is not supported, return an error
@@ -372,22 +683,21 @@ func checkOptsBeginEndTimeToTime(beginEndTimeStr string, date string) (beginEndT
 	}
 
 	// Parse the begin or end time string into a time.Time object using the correct format
-	var newTimeStr string
+	var timeStamp int64
 	// Check if the begin or end time string is not empty
 	if beginEndTimeStr != "" {
-		// If the format of the begin or end time is TimeFormat, concatenate the date with the begin time string
+		// If the format of the begin or end time is TimeFormat, put the begin or end time on the date
 		if beginEndTimeType == TimeFormat {
-			newTimeStr = date + " " + beginEndTimeStr
+			timeStamp, err = TimeOnDate(date, beginEndTimeStr, location)
 		}
 		// If the format of the begin or end time is DatetimeFormat, use the begin or end time string as received
 		if beginEndTimeType == DatetimeFormat {
-			newTimeStr = beginEndTimeStr
+			timeStamp, err = TimeValue(beginEndTimeStr, location)
 		}
-		// Parse the begin or end time string into a time.Time object using the DefaultDateTimeFormatStr format
-		beginEndTime, err = time.Parse(DefaultDateTimeFormatStr, newTimeStr)
 		if err != nil {
 			return
 		}
+		beginEndTime = time.Unix(timeStamp, 0).In(location)
 	}
 
 	// Return the beginEndTimeType, beginEndTime and err values
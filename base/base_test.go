@@ -363,7 +363,7 @@ func Test_Check_CheckOpts_BaseTime(t *testing.T) {
 
 	// verify
 	for i := 0; i < len(tests); i++ {
-		require.Equal(t, tests[i].err, (tests[i].opts).CheckOpts())
+		require.ErrorIs(t, (tests[i].opts).CheckOpts(), tests[i].err)
 	}
 }
 
@@ -415,11 +415,11 @@ func Test_Check_CheckOpts_Location(t *testing.T) {
 				BaseTime:  "1970-01-01 01:01:01",
 				Location:  "",
 				Duration:  1 * time.Nanosecond,
-				BaseList:  []string{"1970-01-01"}, // invalid
+				BaseList:  []string{"1970-01-01"}, // a date, valid
 				BeginTime: "",
 				EndTime:   "",
 			},
-			err: ErrUnSupportedBaseList,
+			err: nil,
 		},
 		{
 			opts: Opts{
@@ -523,7 +523,7 @@ func Test_Check_CheckOpts_Location(t *testing.T) {
 	}
 
 	for i := 0; i < len(tests); i++ {
-		require.Equal(t, tests[i].err, (tests[i].opts).CheckOpts())
+		require.ErrorIs(t, (tests[i].opts).CheckOpts(), tests[i].err)
 	}
 }
 
@@ -586,11 +586,11 @@ func Test_Check_CheckOpts_Baselist(t *testing.T) {
 				BaseTime:  "2023-03-05 03:04:05",
 				Location:  "",
 				Duration:  0 * time.Nanosecond,
-				BaseList:  []string{"2023-03-06"}, // invalid
+				BaseList:  []string{"2023-03-06"}, // a date, valid
 				BeginTime: "",
 				EndTime:   "",
 			},
-			err: ErrUnSupportedBaseList,
+			err: nil,
 		},
 		{
 			opts: Opts{
@@ -640,7 +640,7 @@ func Test_Check_CheckOpts_Baselist(t *testing.T) {
 
 	// verify
 	for i := 0; i < len(tests); i++ {
-		require.Equal(t, tests[i].err, (tests[i].opts).CheckOpts())
+		require.ErrorIs(t, (tests[i].opts).CheckOpts(), tests[i].err)
 	}
 }
 
@@ -724,7 +724,7 @@ func Test_Check_CheckOpts_BeginEndTime(t *testing.T) {
 
 	// verify
 	for i := 0; i < len(tests); i++ {
-		require.Equal(t, tests[i].err, (tests[i].opts).CheckOpts())
+		require.ErrorIs(t, (tests[i].opts).CheckOpts(), tests[i].err)
 	}
 }
 
@@ -754,13 +754,34 @@ func Test_Check_checkOptsBaseList(t *testing.T) {
 			date:          "",
 			expectedError: nil,
 		},
+		// Test case: Valid baseList with DateFormat
+		{
+			name:          "Valid baseList with DateFormat",
+			baseList:      []string{"2023-09-01", "2023-10-01", "2023-11-01"},
+			date:          "2023-01-01",
+			expectedError: nil,
+		},
+		// Test case: Valid baseList with dates mixed with date-times
+		{
+			name:          "Valid baseList with dates mixed with date-times",
+			baseList:      []string{"2023-09-01", "2023-09-01 08:00:00", "2023-W40-1"},
+			date:          "2023-01-01",
+			expectedError: nil,
+		},
 		// Test case: Invalid baseList with unsupported format
 		{
 			name:          "Invalid baseList with unsupported format",
-			baseList:      []string{"2023-09-01", "2023-10-01", "2023-11-01"},
+			baseList:      []string{"09:00:00", ""},
 			date:          "2023-01-01",
 			expectedError: ErrUnSupportedBaseList,
 		},
+		// Test case: Invalid baseList with dates mixed with times
+		{
+			name:          "Invalid baseList with dates mixed with times",
+			baseList:      []string{"2023-09-01", "10:00:00"},
+			date:          "2023-01-01",
+			expectedError: ErrBaseListDifferentTypes,
+		},
 		// Test case: Invalid baseList with different time formats
 		{
 			name:          "Invalid baseList with different time formats",
@@ -780,9 +801,9 @@ func Test_Check_checkOptsBaseList(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			// Call function with test inputs
-			err := checkOptsBaseList(test.baseList, test.date)
+			err := checkOptsBaseList(test.baseList, test.date, "")
 			// Check if returned error matches expected error
-			require.Equal(t, test.expectedError, err)
+			require.ErrorIs(t, err, test.expectedError)
 		})
 	}
 }
@@ -841,3 +862,179 @@ func Test_Check_checkOptsBeginEndTimeToTime(t *testing.T) {
 		})
 	}
 }
+
+// Test_Check_CheckOpts_Buffer is testing the validation of the buffer size and the overflow policy in the CheckOpts function.
+func Test_Check_CheckOpts_Buffer(t *testing.T) {
+	// test cases
+	tests := []struct {
+		bufferSize     int
+		overflowPolicy uint
+		err            error
+	}{
+		// valid
+		{0, 0, nil},
+		{0, OverflowBlock, nil},
+		{5, OverflowBlock, nil},
+		{5, OverflowDropNewest, nil},
+		{5, OverflowDropOldest, nil},
+		{5, OverflowCoalesce, nil},
+		// invalid
+		{-1, OverflowBlock, ErrNegativeBufferSize},
+		{5, OverflowCoalesce + 1, ErrUnSupportedOverflowPolicy},
+		{0, OverflowDropNewest, ErrOverflowPolicyWithoutBuffer},
+		{0, OverflowDropOldest, ErrOverflowPolicyWithoutBuffer},
+		{0, OverflowCoalesce, ErrOverflowPolicyWithoutBuffer},
+	}
+
+	// verify
+	for i := 0; i < len(tests); i++ {
+		opts := Opts{
+			BaseTime:       "03:04:05",
+			BufferSize:     tests[i].bufferSize,
+			OverflowPolicy: tests[i].overflowPolicy,
+		}
+		require.ErrorIs(t, opts.CheckOpts(), tests[i].err)
+	}
+}
+
+// Test_Check_OffOpts_IsOff checks the days off of the options.
+func Test_Check_OffOpts_IsOff(t *testing.T) {
+	require.True(t, OffOpts{EveryDayOff: true}.IsOff(time.Wednesday))
+	require.True(t, OffOpts{SaturdayOff: true}.IsOff(time.Saturday))
+	require.False(t, OffOpts{SaturdayOff: true}.IsOff(time.Sunday))
+	require.False(t, OffOpts{}.IsOff(time.Monday))
+}
+
+// Test_Check_CheckOpts_Precision is testing the validation of the precision in the CheckOpts function.
+func Test_Check_CheckOpts_Precision(t *testing.T) {
+	opts := Opts{BaseTime: "03:04:05", Precision: time.Millisecond}
+	require.NoError(t, opts.CheckOpts())
+	opts.Precision = -time.Millisecond
+	require.ErrorIs(t, opts.CheckOpts(), ErrNegativePrecision)
+}
+
+// Test_Check_CheckOpts_Mode is testing the validation of the scheduling mode in the CheckOpts function.
+func Test_Check_CheckOpts_Mode(t *testing.T) {
+	// test cases
+	tests := []struct {
+		mode     uint
+		duration time.Duration
+		err      error
+	}{
+		// valid
+		{0, 0, nil},
+		{HumanMode, 0, nil},
+		{CommputerMode, time.Second, nil},
+		// invalid
+		{CommputerMode, 0, ErrModeWithoutDuration},
+		{CommputerMode + 1, time.Second, ErrUnSupportedMode},
+	}
+
+	// verify
+	for i := 0; i < len(tests); i++ {
+		opts := Opts{
+			BaseTime: "03:04:05",
+			Duration: tests[i].duration,
+			Mode:     tests[i].mode,
+		}
+		require.ErrorIs(t, opts.CheckOpts(), tests[i].err)
+	}
+}
+
+// Test_Check_CheckOpts_Overlap is testing the validation of the overlap policy in the CheckOpts function.
+func Test_Check_CheckOpts_Overlap(t *testing.T) {
+	// test cases
+	tests := []struct {
+		policy    uint
+		tolerance time.Duration
+		baseList  []string
+		err       error
+	}{
+		// valid
+		{0, 0, []string{"03:04:15"}, nil},
+		{OverlapFireBoth, 0, []string{"03:04:15"}, nil},
+		{OverlapError, 0, []string{"03:04:16"}, nil},
+		{OverlapError, time.Second, []string{"03:04:17"}, nil},
+		// invalid
+		{OverlapFireBoth + 1, 0, nil, ErrUnSupportedOverlapPolicy},
+		{OverlapMerge, -time.Second, nil, ErrNegativeOverlapTolerance},
+		{OverlapError, 0, []string{"03:04:15"}, ErrBaseListOverlaps},
+		{OverlapError, 2 * time.Second, []string{"03:04:17"}, ErrBaseListOverlaps},
+	}
+
+	// verify
+	for i := 0; i < len(tests); i++ {
+		opts := Opts{
+			BaseTime:         "03:04:05",
+			Duration:         10 * time.Second,
+			BaseList:         tests[i].baseList,
+			OverlapPolicy:    tests[i].policy,
+			OverlapTolerance: tests[i].tolerance,
+		}
+		require.ErrorIs(t, opts.CheckOpts(), tests[i].err)
+	}
+
+	// The overlapping element is reported with its index
+	opts := Opts{
+		BaseTime:      "03:04:05",
+		Duration:      10 * time.Second,
+		BaseList:      []string{"03:04:06", "03:04:25"},
+		OverlapPolicy: OverlapError,
+	}
+	var validation *ValidationError
+	require.ErrorAs(t, opts.CheckOpts(), &validation)
+	require.Len(t, validation.Problems, 1)
+	require.Equal(t, "BaseList", validation.Problems[0].Field)
+	require.Equal(t, 1, validation.Problems[0].Index)
+
+	// The overlaps are checked against the repeat phase on every date, not only on the current date
+	phases := []struct {
+		baseTime string
+		baseList []string
+		err      error
+	}{
+		// A point every 7 hours from a fixed time is on 05:04:05 four days later
+		{"2023-01-31 03:04:05", []string{"05:04:05"}, ErrBaseListOverlaps},
+		{"2023-01-31 03:04:05", []string{"05:34:05"}, nil},
+		// A clock time repeats from the date of an element with a date
+		{"03:04:05", []string{"2023-02-04 17:04:05"}, ErrBaseListOverlaps},
+		{"03:04:05", []string{"2023-02-04 17:34:05"}, nil},
+	}
+	for i := 0; i < len(phases); i++ {
+		opts := Opts{
+			BaseTime:      phases[i].baseTime,
+			Duration:      7 * time.Hour,
+			BaseList:      phases[i].baseList,
+			OverlapPolicy: OverlapError,
+		}
+		require.ErrorIs(t, opts.CheckOpts(), phases[i].err, phases[i].baseTime)
+	}
+}
+
+// Test_Check_CheckOpts_AllDayTime is testing the dates in the baseList with the time of day they fire at.
+func Test_Check_CheckOpts_AllDayTime(t *testing.T) {
+	// test cases
+	tests := []struct {
+		allDayTime string
+		baseList   []string
+		err        error
+	}{
+		// valid
+		{"", []string{"2023-09-01", "2023-09-01 08:00:00"}, nil},
+		{"07:00:00", []string{"2023-09-01", "2023-09-01 08:00:00"}, nil},
+		{"9:30 AM [Europe/London]", []string{"2023-09-01 [Asia/Tokyo]"}, nil},
+		// invalid
+		{"09:00:00", []string{"2023-09-01", "2023-09-01 08:00:00"}, ErrIncorrectBaseListOrder},
+		{"2023-09-01", []string{"2023-09-01"}, ErrUnSupportedAllDayTime},
+	}
+
+	// verify
+	for i := 0; i < len(tests); i++ {
+		opts := Opts{
+			BaseTime:   "03:04:05",
+			BaseList:   tests[i].baseList,
+			AllDayTime: tests[i].allDayTime,
+		}
+		require.ErrorIs(t, opts.CheckOpts(), tests[i].err)
+	}
+}
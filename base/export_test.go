@@ -0,0 +1,8 @@
+package base
+
+// MissDefaultLocation pretends the time zone database lacks the default location, until SetDefaultLocation is called.
+func MissDefaultLocation(name string) {
+	locationMu.Lock()
+	defaultLocationName, defaultLocation = name, nil
+	locationMu.Unlock()
+}
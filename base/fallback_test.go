@@ -0,0 +1,41 @@
+package base_test
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/panhongrainbow/tickerz/goTicker"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_DefaultLocation_Fallback checks that a ticker without a location is built in UTC when the default location is missing.
+func Test_Check_DefaultLocation_Fallback(t *testing.T) {
+	// Pretend the time zone database lacks the default location
+	tickerBase.MissDefaultLocation("Missing/Zone")
+	defer func() {
+		require.NoError(t, tickerBase.SetDefaultLocation(tickerBase.DefaultTimeZone))
+	}()
+
+	// The options without a location are valid, and keep the location empty
+	opts := tickerBase.Opts{BaseTime: "9:0:0", Duration: time.Hour, BeginTime: "8:0:0", EndTime: "18:0:0"}
+	require.NoError(t, opts.CheckOpts())
+	require.Equal(t, "", opts.Location)
+
+	// The ticker is built in UTC without an error, and reports the fallback
+	gt, err := goTicker.New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.True(t, gt.LocationFallback())
+	require.Equal(t, time.UTC, gt.BaseLocation)
+	require.NoError(t, gt.MockTime(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC).Unix(), gt.BaseStamp)
+
+	// UpdateOpts accepts the options in the same way
+	require.NoError(t, gt.UpdateOpts(opts, tickerBase.OffOpts{}))
+	require.True(t, gt.LocationFallback())
+
+	// A location given by name is not affected
+	opts.Location = "Asia/Tokyo"
+	gt, err = goTicker.New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.False(t, gt.LocationFallback())
+}
@@ -0,0 +1,196 @@
+package base
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// special layouts of the format registry, which time.Parse does not understand
+const (
+	UnixLayout    = "unix"       // Unix seconds after an @, such as "@1675137600", which is a date-time
+	ISOWeekLayout = "2006-W01-1" // ISO week dates, such as "2023-W05-2", which are dates
+)
+
+// TimeLayout is an input format of time strings in the format registry.
+type TimeLayout struct {
+	Layout string // a layout of time.Parse, UnixLayout or ISOWeekLayout
+	Type   uint   // DateFormat, TimeFormat or DatetimeFormat
+}
+
+var (
+	layoutMu    sync.RWMutex
+	timeLayouts = DefaultTimeLayouts()
+)
+
+/*
+DefaultTimeLayouts returns the formats the registry starts with.
+Besides the default formats, they include RFC 3339 with its offset, times without seconds, 12-hour clocks,
+ISO week dates and Unix seconds.
+*/
+func DefaultTimeLayouts() (output []TimeLayout) {
+	// Return the output value
+	return []TimeLayout{
+		{Layout: DefaultDateFormatStr, Type: DateFormat},
+		{Layout: DefaultTimeFormatStr, Type: TimeFormat},
+		{Layout: DefaultDateTimeFormatStr, Type: DatetimeFormat},
+		{Layout: time.RFC3339, Type: DatetimeFormat},
+		{Layout: "15:4", Type: TimeFormat},
+		{Layout: "3:4:5 PM", Type: TimeFormat},
+		{Layout: "3:4 PM", Type: TimeFormat},
+		{Layout: ISOWeekLayout, Type: DateFormat},
+		{Layout: UnixLayout, Type: DatetimeFormat},
+	}
+}
+
+// RegisterTimeLayout adds a format to the registry, which is tried after the formats already in it.
+func RegisterTimeLayout(layout TimeLayout) (err error) {
+	// Only a date, a time or a date-time can be registered
+	if layout.Layout == "" || (layout.Type != DateFormat && layout.Type != TimeFormat && layout.Type != DatetimeFormat) {
+		err = ErrUnSupportedTimeLayout
+		return
+	}
+
+	// Add the format
+	layoutMu.Lock()
+	timeLayouts = append(timeLayouts, layout)
+	layoutMu.Unlock()
+
+	// Return err value
+	return
+}
+
+// TimeLayouts returns the formats in the registry, in the order they are tried.
+func TimeLayouts() (output []TimeLayout) {
+	layoutMu.RLock()
+	output = append(output, timeLayouts...)
+	layoutMu.RUnlock()
+
+	// Return the output value
+	return
+}
+
+// ResetTimeLayouts puts the registry back to DefaultTimeLayouts.
+func ResetTimeLayouts() {
+	layoutMu.Lock()
+	timeLayouts = DefaultTimeLayouts()
+	layoutMu.Unlock()
+}
+
+/*
+matchTime parses str with the first format in the registry which accepts it.
+A date and a time joined by a space make a date-time as well, such as "2023-1-31 9:30 AM".
+The value is in UTC unless the format carries an offset, which is reported by zoned.
+*/
+func matchTime(str string) (value time.Time, tType uint, zoned bool, ok bool) {
+	// Try every format in the registry
+	for _, layout := range TimeLayouts() {
+		value, zoned, ok = parseLayout(layout.Layout, str)
+		if ok {
+			tType = layout.Type
+			return
+		}
+	}
+
+	// Try a date and a time joined by a space
+	i := strings.IndexByte(str, ' ')
+	if i <= 0 {
+		return
+	}
+	date, dateType, _, dateOk := matchTime(str[:i])
+	clock, clockType, _, clockOk := matchTime(str[i+1:])
+	if !dateOk || !clockOk || dateType != DateFormat || clockType != TimeFormat {
+		return
+	}
+	value = time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+	tType, zoned, ok = DatetimeFormat, false, true
+
+	// Return the value, tType, zoned and ok values
+	return
+}
+
+// parseLayout parses str with one format of the registry.
+func parseLayout(layout, str string) (value time.Time, zoned bool, ok bool) {
+	switch layout {
+	case UnixLayout:
+		// Only plain digits after an @ are Unix seconds, so a time such as "9" with a typo is not taken for one
+		digits := strings.TrimPrefix(str, "@")
+		if digits == str || digits == "" || strings.Trim(digits, "0123456789") != "" {
+			return
+		}
+		seconds, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return
+		}
+		return time.Unix(seconds, 0).UTC(), true, true
+	case ISOWeekLayout:
+		return parseISOWeek(str)
+	}
+
+	// Parse with time.Parse, where a format with an offset keeps it
+	value, err := time.Parse(layout, str)
+	if err != nil {
+		return
+	}
+	zoned = strings.Contains(layout, "Z07") || strings.Contains(layout, "-07") || strings.Contains(layout, "MST")
+	ok = true
+
+	// Return the value, zoned and ok values
+	return
+}
+
+// parseISOWeek parses an ISO week date such as "2023-W05-2", which is the Tuesday of the fifth week of 2023.
+func parseISOWeek(str string) (value time.Time, zoned bool, ok bool) {
+	// Split the year, the week and the day of the week
+	var year, week, day int
+	parts := strings.Split(str, "-")
+	if len(parts) != 3 || len(parts[0]) != 4 || len(parts[1]) != 3 || parts[1][0] != 'W' || len(parts[2]) != 1 {
+		return
+	}
+	var err error
+	if year, err = strconv.Atoi(parts[0]); err != nil {
+		return
+	}
+	if week, err = strconv.Atoi(parts[1][1:]); err != nil || week < 1 || week > 53 {
+		return
+	}
+	if day, err = strconv.Atoi(parts[2]); err != nil || day < 1 || day > 7 {
+		return
+	}
+
+	// The first week is the one with January 4th, and weeks start on Monday
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	value = monday.AddDate(0, 0, (week-1)*7+day-1)
+
+	// The 53rd week only exists in long years
+	if week == 53 {
+		if _, w := value.ISOWeek(); w != 53 {
+			return
+		}
+	}
+	ok = true
+
+	// Return the value, zoned and ok values
+	return
+}
+
+// dateTimeValue parses a date-time string of the registry, putting its wall clock in the location unless it carries an offset.
+func dateTimeValue(str string, location *time.Location) (value time.Time, err error) {
+	// Only a date-time has a value on its own
+	parsed, tType, zoned, ok := matchTime(str)
+	if !ok || tType != DatetimeFormat {
+		err = ErrTimeParsion
+		return
+	}
+
+	// An offset overrides the location
+	value = parsed
+	if !zoned {
+		value = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, location)
+	}
+
+	// Return the value and err values
+	return
+}
@@ -0,0 +1,104 @@
+package base
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_TimeLayouts checks the formats of the registry, and that registered formats are accepted.
+func Test_Check_TimeLayouts(t *testing.T) {
+	// Load the location
+	location, err := time.LoadLocation(DefaultTimeZone)
+	require.NoError(t, err)
+
+	t.Run("time types", func(t *testing.T) {
+		tests := []struct {
+			timeStr  string
+			timeType uint
+			err      error
+		}{
+			{"2023-01-31T12:00:00+09:00", DatetimeFormat, nil},
+			{"2023-01-31T12:00:00Z", DatetimeFormat, nil},
+			{"15:04", TimeFormat, nil},
+			{"9:30 AM", TimeFormat, nil},
+			{"9:30:15 PM", TimeFormat, nil},
+			{"2023-W05-2", DateFormat, nil},
+			{"@1675137600", DatetimeFormat, nil},
+			{"2023-1-31 9:30 PM", DatetimeFormat, nil},
+			{"13:30 PM", 0, ErrUnSupportedTimeFormat},
+			{"2023-W54-1", 0, ErrUnSupportedTimeFormat},
+			{"2022-W53-1", 0, ErrUnSupportedTimeFormat},
+			{"-1675137600", 0, ErrUnSupportedTimeFormat},
+			// Digits without the @ are not Unix seconds
+			{"1675137600", 0, ErrUnSupportedTimeFormat},
+			{"9", 0, ErrUnSupportedTimeFormat},
+			{"15", 0, ErrUnSupportedTimeFormat},
+			{"930", 0, ErrUnSupportedTimeFormat},
+			{"@", 0, ErrUnSupportedTimeFormat},
+			{"@-1", 0, ErrUnSupportedTimeFormat},
+			{"@93O", 0, ErrUnSupportedTimeFormat},
+		}
+		for i := 0; i < len(tests); i++ {
+			tp, err := TimeType(tests[i].timeStr)
+			require.Equal(t, tests[i].timeType, tp, tests[i].timeStr)
+			require.Equal(t, tests[i].err, err, tests[i].timeStr)
+		}
+	})
+	t.Run("time values", func(t *testing.T) {
+		tests := []struct {
+			timeStr string
+			value   time.Time
+		}{
+			// The offset overrides the location
+			{"2023-01-31T12:00:00+09:00", time.Date(2023, 1, 31, 12, 0, 0, 0, time.FixedZone("", 9*3600))},
+			{"@1675137600", time.Unix(1675137600, 0)},
+			// The wall clock is taken in the location
+			{"2023-1-31 9:30 PM", time.Date(2023, 1, 31, 21, 30, 0, 0, location)},
+			{"2023-1-31 15:04", time.Date(2023, 1, 31, 15, 4, 0, 0, location)},
+			{"2023-W05-2 9:30 AM", time.Date(2023, 1, 31, 9, 30, 0, 0, location)},
+		}
+		for i := 0; i < len(tests); i++ {
+			stamp, err := TimeValue(tests[i].timeStr, location)
+			require.NoError(t, err, tests[i].timeStr)
+			require.Equal(t, tests[i].value.Unix(), stamp, tests[i].timeStr)
+		}
+		_, err := TimeValue("2023-1-31", location)
+		require.Equal(t, ErrTimeParsion, err)
+
+		// A base time with a typo is not taken for Unix seconds
+		opts := Opts{BaseTime: "9"}
+		require.ErrorIs(t, opts.CheckOpts(), ErrUnSupportedTimeFormat)
+	})
+	t.Run("options in the new formats", func(t *testing.T) {
+		opts := Opts{
+			BaseTime:  "12:00",
+			BaseList:  []string{"9:30 AM", "1:15 PM"},
+			BeginTime: "8:00 AM",
+			EndTime:   "18:00",
+		}
+		require.NoError(t, opts.CheckOpts())
+		opts.BaseList = []string{"1:15 PM", "9:30 AM"}
+		require.ErrorIs(t, opts.CheckOpts(), ErrIncorrectBaseListOrder)
+	})
+	t.Run("register a format", func(t *testing.T) {
+		defer ResetTimeLayouts()
+		_, err := TimeType("31/01/2023")
+		require.Equal(t, ErrUnSupportedTimeFormat, err)
+
+		// Register a day-first date
+		require.Equal(t, ErrUnSupportedTimeLayout, RegisterTimeLayout(TimeLayout{Layout: "2/1/2006", Type: EmptyTimeFormat}))
+		require.NoError(t, RegisterTimeLayout(TimeLayout{Layout: "2/1/2006", Type: DateFormat}))
+		require.Len(t, TimeLayouts(), len(DefaultTimeLayouts())+1)
+		tp, err := TimeType("31/01/2023")
+		require.NoError(t, err)
+		require.Equal(t, DateFormat, tp)
+		stamp, err := TimeValue("31/01/2023 12:00", location)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 12, 0, 0, 0, location).Unix(), stamp)
+
+		// Reset the registry
+		ResetTimeLayouts()
+		require.Equal(t, DefaultTimeLayouts(), TimeLayouts())
+	})
+}
@@ -0,0 +1,128 @@
+package base
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxOffset is the largest offset from UTC a fixed-offset location can have.
+const maxOffset = 14 * time.Hour
+
+var (
+	locationMu          sync.Mutex
+	defaultLocationName = DefaultTimeZone
+	defaultLocation     *time.Location // loaded on first use
+)
+
+/*
+SetDefaultLocation changes the location used when Opts.Location is empty or no location is given.
+The location is loaded right away, and the default is left as it is if the name is not supported.
+*/
+func SetDefaultLocation(name string) (err error) {
+	// Load the new location
+	var location *time.Location
+	location, err = LoadLocation(name)
+	if err != nil {
+		return
+	}
+
+	// Replace the default
+	locationMu.Lock()
+	defaultLocationName, defaultLocation = name, location
+	locationMu.Unlock()
+
+	// Return err value
+	return
+}
+
+// DefaultLocationName returns the name of the default location, which is DefaultTimeZone unless SetDefaultLocation changed it.
+func DefaultLocationName() (name string) {
+	locationMu.Lock()
+	defer locationMu.Unlock()
+
+	// Return the name value
+	return defaultLocationName
+}
+
+/*
+DefaultLocation returns the default location, which is loaded the first time it is needed.
+If the time zone database is missing, as in minimal containers, it returns UTC with ErrDefaultLocationFallback,
+and tries again on the next call. Building with the tickerz_tzdata tag embeds the database, so this does not happen.
+*/
+func DefaultLocation() (location *time.Location, err error) {
+	locationMu.Lock()
+	defer locationMu.Unlock()
+
+	// Load the location the first time
+	if defaultLocation == nil {
+		defaultLocation, err = LoadLocation(defaultLocationName)
+		if err != nil {
+			defaultLocation = nil
+			location, err = time.UTC, ErrDefaultLocationFallback
+			return
+		}
+	}
+	location = defaultLocation
+
+	// Return the location and err values
+	return
+}
+
+/*
+LoadLocation loads a location by its name like time.LoadLocation,
+and also accepts fixed offsets from UTC such as "+08:00", "-0530" or "+8".
+It returns ErrUnSupportedLocation for a name it does not know.
+*/
+func LoadLocation(name string) (location *time.Location, err error) {
+	// A name with a sign is a fixed offset
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") {
+		var offset time.Duration
+		offset, err = parseOffset(name)
+		if err != nil {
+			return
+		}
+		location = time.FixedZone(name, int(offset/time.Second))
+		return
+	}
+
+	// Load the location from the time zone database
+	location, err = time.LoadLocation(name)
+	if err != nil {
+		err = ErrUnSupportedLocation
+	}
+
+	// Return the location and err values
+	return
+}
+
+// parseOffset parses a fixed offset from UTC, which is a sign followed by hours, and optionally minutes with or without a colon.
+func parseOffset(name string) (offset time.Duration, err error) {
+	// Split the hours and the minutes
+	digits := strings.Replace(name[1:], ":", "", 1)
+	hours, minutes := digits, "0"
+	if len(digits) > 2 {
+		hours, minutes = digits[:len(digits)-2], digits[len(digits)-2:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || len(hours) > 2 ||
+		(strings.Contains(name, ":") && len(minutes) != 2) {
+		err = ErrUnSupportedLocation
+		return
+	}
+
+	// Convert them into the offset
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	offset = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	if m >= 60 || offset > maxOffset {
+		err = ErrUnSupportedLocation
+		return
+	}
+	if name[0] == '-' {
+		offset = -offset
+	}
+
+	// Return the offset and err values
+	return
+}
@@ -0,0 +1,77 @@
+package base
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Location checks fixed-offset locations and the configurable default location.
+func Test_Check_Location(t *testing.T) {
+	t.Run("fixed offsets", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			offset int
+			err    error
+		}{
+			{"+08:00", 8 * 3600, nil},
+			{"-0530", -(5*3600 + 30*60), nil},
+			{"+8", 8 * 3600, nil},
+			{"+14:00", 14 * 3600, nil},
+			{"+15:00", 0, ErrUnSupportedLocation},
+			{"+08:60", 0, ErrUnSupportedLocation},
+			{"+08:", 0, ErrUnSupportedLocation},
+			{"+", 0, ErrUnSupportedLocation},
+			{"+8h", 0, ErrUnSupportedLocation},
+		}
+		for i := 0; i < len(tests); i++ {
+			location, err := LoadLocation(tests[i].name)
+			require.Equal(t, tests[i].err, err, tests[i].name)
+			if err == nil {
+				_, offset := time.Date(2023, 1, 31, 0, 0, 0, 0, location).Zone()
+				require.Equal(t, tests[i].offset, offset, tests[i].name)
+			}
+		}
+
+		// The options accept a fixed offset as well
+		opts := Opts{BaseTime: "0:0:0", Location: "+08:00"}
+		require.NoError(t, opts.CheckOpts())
+	})
+	t.Run("default location", func(t *testing.T) {
+		defer func() {
+			require.NoError(t, SetDefaultLocation(DefaultTimeZone))
+		}()
+		require.Equal(t, DefaultTimeZone, DefaultLocationName())
+
+		// An unsupported name leaves the default as it is
+		require.Equal(t, ErrUnSupportedLocation, SetDefaultLocation("Mars/Olympus"))
+		require.Equal(t, DefaultTimeZone, DefaultLocationName())
+
+		// Empty options take the new default
+		require.NoError(t, SetDefaultLocation("Europe/London"))
+		opts := Opts{BaseTime: "0:0:0"}
+		require.NoError(t, opts.CheckOpts())
+		require.Equal(t, "Europe/London", opts.Location)
+		stamp, err := TimeValue("2023-1-31 9:0:0", nil)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC).Unix(), stamp)
+	})
+	t.Run("fall back to UTC", func(t *testing.T) {
+		// Pretend the time zone database lacks the default location
+		locationMu.Lock()
+		defaultLocationName, defaultLocation = "Missing/Zone", nil
+		locationMu.Unlock()
+		defer func() {
+			require.NoError(t, SetDefaultLocation(DefaultTimeZone))
+		}()
+
+		location, err := DefaultLocation()
+		require.Equal(t, ErrDefaultLocationFallback, err)
+		require.Equal(t, time.UTC, location)
+
+		// The value is still taken in UTC, with the error returned
+		stamp, err := TimeValue("2023-1-31 9:0:0", nil)
+		require.Equal(t, ErrDefaultLocationFallback, err)
+		require.Equal(t, time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC).Unix(), stamp)
+	})
+}
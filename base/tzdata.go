@@ -0,0 +1,6 @@
+//go:build tickerz_tzdata
+
+package base
+
+// Embed the time zone database, so the locations can be loaded on systems without tzdata.
+import _ "time/tzdata"
@@ -0,0 +1,79 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError is one problem of the options, found by CheckOpts.
+type FieldError struct {
+	Field string // the name of the field in Opts
+	Index int    // the index of the element for BaseList, -1 for the other fields
+	Value string // the offending value
+	Err   error  // the reason, which is one of the Err constants
+}
+
+// Error describes the problem with the field, the index and the value.
+func (receive *FieldError) Error() string {
+	field := receive.Field
+	if receive.Index >= 0 {
+		field = fmt.Sprintf("%s[%d]", field, receive.Index)
+	}
+	return fmt.Sprintf("%s %q: %s", field, receive.Value, receive.Err)
+}
+
+// Unwrap returns the reason, so errors.Is finds the Err constants.
+func (receive *FieldError) Unwrap() error {
+	return receive.Err
+}
+
+/*
+ValidationError lists every problem CheckOpts found in the options, in the order of the fields.
+errors.Is reports every Err constant among the reasons, and errors.As finds every FieldError.
+*/
+type ValidationError struct {
+	Problems []*FieldError
+}
+
+// Error joins the descriptions of all the problems.
+func (receive *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(receive.Problems))
+	for i := 0; i < len(receive.Problems); i++ {
+		descriptions = append(descriptions, receive.Problems[i].Error())
+	}
+	return "invalid options: " + strings.Join(descriptions, "; ")
+}
+
+// Unwrap returns the problems.
+func (receive *ValidationError) Unwrap() (output []error) {
+	for i := 0; i < len(receive.Problems); i++ {
+		output = append(output, receive.Problems[i])
+	}
+
+	// Return the output value
+	return
+}
+
+// add records a problem of a field.
+func (receive *ValidationError) add(field string, index int, value string, err error) {
+	receive.Problems = append(receive.Problems, &FieldError{Field: field, Index: index, Value: value, Err: err})
+}
+
+// merge records the problems of another validation error.
+func (receive *ValidationError) merge(err error) {
+	var other *ValidationError
+	if errors.As(err, &other) {
+		receive.Problems = append(receive.Problems, other.Problems...)
+	}
+}
+
+// orNil returns the validation error, or nil when there is no problem.
+func (receive *ValidationError) orNil() (err error) {
+	if len(receive.Problems) > 0 {
+		err = receive
+	}
+
+	// Return err value
+	return
+}
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_ValidationError checks that CheckOpts lists every problem with its field, index and value.
+func Test_Check_ValidationError(t *testing.T) {
+	// Options with a problem in several fields
+	opts := Opts{
+		BaseTime:  "25:0:0",
+		Location:  "Mars/Olympus",
+		Duration:  -time.Second,
+		BaseList:  []string{"9:0:0", "2023-1-31", "8:0:0", "2023-1-31 10:0:0"},
+		BeginTime: "10:0:0",
+		EndTime:   "9:0:0",
+	}
+	err := opts.CheckOpts()
+
+	// Every problem is reported in the order of the fields
+	var validation *ValidationError
+	require.True(t, errors.As(err, &validation))
+	require.Equal(t, []*FieldError{
+		{Field: "BaseTime", Index: -1, Value: "25:0:0", Err: ErrUnSupportedTimeFormat},
+		{Field: "Location", Index: -1, Value: "Mars/Olympus", Err: ErrUnSupportedLocation},
+		{Field: "Duration", Index: -1, Value: "-1s", Err: ErrNegativeDuration},
+		{Field: "BaseList", Index: 1, Value: "2023-1-31", Err: ErrBaseListDifferentTypes},
+		{Field: "BaseList", Index: 2, Value: "8:0:0", Err: ErrIncorrectBaseListOrder},
+		{Field: "BaseList", Index: 3, Value: "2023-1-31 10:0:0", Err: ErrBaseListDifferentTypes},
+		{Field: "EndTime", Index: -1, Value: "9:0:0", Err: ErrIncorrectBeginEndTimeOrder},
+	}, validation.Problems)
+
+	// errors.Is finds every reason
+	require.ErrorIs(t, err, ErrUnSupportedLocation)
+	require.ErrorIs(t, err, ErrIncorrectBaseListOrder)
+	require.NotErrorIs(t, err, ErrNegativePrecision)
+
+	// errors.As finds the first field
+	var field *FieldError
+	require.True(t, errors.As(err, &field))
+	require.Equal(t, "BaseTime", field.Field)
+
+	// The description names the field, the index and the value
+	require.Equal(t, `BaseList[2] "8:0:0": incorrect base list order`, validation.Problems[4].Error())
+	require.Contains(t, err.Error(), `Location "Mars/Olympus": unsupported location`)
+
+	// Valid options give a nil error rather than an empty list
+	opts = Opts{BaseTime: "0:0:0"}
+	require.Nil(t, opts.CheckOpts())
+}
@@ -0,0 +1,133 @@
+package base
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// zoneCache keeps the locations loaded for the zones of time strings, by name.
+var zoneCache sync.Map
+
+/*
+SplitZone splits the zone off a time string, such as "Asia/Tokyo" of "9:0:0 [Asia/Tokyo]",
+and returns the location of the zone, or nil when the string has no zone.
+*/
+func SplitZone(str string) (timeStr string, location *time.Location, err error) {
+	// A zone is a suffix in square brackets after a space
+	timeStr = str
+	i := strings.LastIndex(str, " [")
+	if i < 0 || !strings.HasSuffix(str, "]") {
+		return
+	}
+	timeStr = str[:i]
+
+	// Load the zone, which is only done once for every name
+	name := str[i+2 : len(str)-1]
+	if cached, ok := zoneCache.Load(name); ok {
+		location = cached.(*time.Location)
+		return
+	}
+	location, err = LoadLocation(name)
+	if err != nil || name == "" {
+		location, err = nil, ErrUnSupportedLocation
+		return
+	}
+	zoneCache.Store(name, location)
+
+	// Return the timeStr, location and err values
+	return
+}
+
+/*
+TimeOnDate returns the Unix time of a time of day on the date (in DefaultDateFormatStr) in the location.
+A time with its own zone, such as "9:0:0 [America/New_York]", is the occurrence of that local time
+which falls on the date in the location, so its local date may be the day before or after.
+*/
+func TimeOnDate(date string, timeStr string, location *time.Location) (timeStamp int64, err error) {
+	// If no location is specified, use the default location, which may fall back to UTC
+	var fallbackErr error
+	if location == nil {
+		location, fallbackErr = DefaultLocation()
+	}
+
+	// A time without a zone is on the date in the location
+	var clock string
+	var zone *time.Location
+	clock, zone, err = SplitZone(timeStr)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err == nil {
+			err = fallbackErr
+		}
+	}()
+	if zone == nil {
+		timeStamp, err = TimeValue(date+" "+timeStr, location)
+		return
+	}
+
+	// Find the local date of the zone on which the time falls on the date in the location
+	var day time.Time
+	day, err = time.ParseInLocation(DefaultDateFormatStr, date, location)
+	if err != nil {
+		err = ErrTimeParsion
+		return
+	}
+	next := day.AddDate(0, 0, 1)
+	offsets := []int{0, -1, 1}
+	for i := 0; i < len(offsets); i++ {
+		var stamp int64
+		local := day.AddDate(0, 0, offsets[i]).Format(DefaultDateFormatStr)
+		stamp, err = TimeValue(local+" "+clock, zone)
+		if err != nil {
+			return
+		}
+		if i == 0 {
+			timeStamp = stamp
+		}
+		if stamp >= day.Unix() && stamp < next.Unix() {
+			timeStamp = stamp
+			return
+		}
+	}
+
+	// Around a change of daylight saving time the date may be missed, so keep the same local date
+
+	// Return the timeStamp and err values
+	return
+}
+
+/*
+DateAtTime returns the Unix time of a date, such as "2023-2-1" or "2023-W05-3", at a time of day in the location,
+where an empty time of day is the start of the date.
+A date with its own zone, such as "2023-2-1 [Asia/Tokyo]", is in that zone instead of the location.
+*/
+func DateAtTime(dateStr string, timeStr string, location *time.Location) (timeStamp int64, err error) {
+	// A date with its own zone is in that zone
+	var zone *time.Location
+	dateStr, zone, err = SplitZone(dateStr)
+	if err != nil {
+		return
+	}
+	if zone != nil {
+		location = zone
+	}
+
+	// Only a date of the format registry is accepted
+	parsed, tType, _, ok := matchTime(dateStr)
+	if !ok || tType != DateFormat {
+		err = ErrTimeParsion
+		return
+	}
+
+	// Put the time of day on the date, which is midnight if it is empty
+	if timeStr == "" {
+		timeStr = "0:0:0"
+	}
+	timeStamp, err = TimeOnDate(parsed.Format(DefaultDateFormatStr), timeStr, location)
+
+	// Return the timeStamp and err values
+	return
+}
@@ -0,0 +1,90 @@
+package base
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Zone checks time strings with their own zones.
+func Test_Check_Zone(t *testing.T) {
+	// Load the locations
+	shanghai, err := time.LoadLocation(DefaultTimeZone)
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("split the zone", func(t *testing.T) {
+		timeStr, location, err := SplitZone("9:0:0 [America/New_York]")
+		require.NoError(t, err)
+		require.Equal(t, "9:0:0", timeStr)
+		require.Equal(t, newYork.String(), location.String())
+
+		timeStr, location, err = SplitZone("9:0:0")
+		require.NoError(t, err)
+		require.Equal(t, "9:0:0", timeStr)
+		require.Nil(t, location)
+
+		_, _, err = SplitZone("9:0:0 [Mars/Olympus]")
+		require.Equal(t, ErrUnSupportedLocation, err)
+		_, err = TimeType("9:0:0 [Mars/Olympus]")
+		require.Equal(t, ErrUnSupportedLocation, err)
+		tp, err := TimeType("2023-1-31 9:0:0 [America/New_York]")
+		require.NoError(t, err)
+		require.Equal(t, DatetimeFormat, tp)
+	})
+	t.Run("values", func(t *testing.T) {
+		// A date-time is taken in its own zone
+		stamp, err := TimeValue("2023-1-31 9:0:0 [America/New_York]", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 9, 0, 0, 0, newYork).Unix(), stamp)
+
+		// A time falls on the date in the location, which is the same date in New York
+		stamp, err = TimeOnDate("2023-1-31", "9:0:0 [America/New_York]", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 22, 0, 0, 0, shanghai).Unix(), stamp)
+
+		// and the day before in Honolulu
+		stamp, err = TimeOnDate("2023-1-31", "9:0:0 ["+DefaultTestTimeZone+"]", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 3, 0, 0, 0, shanghai).Unix(), stamp)
+
+		// A time without a zone is on the date in the location
+		stamp, err = TimeOnDate("2023-1-31", "9:0:0", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 1, 31, 9, 0, 0, 0, shanghai).Unix(), stamp)
+	})
+	t.Run("options with zones", func(t *testing.T) {
+		// The zoned elements do not have to be in order
+		opts := Opts{
+			BaseTime:  "0:0:0",
+			BaseList:  []string{"9:0:0 [Asia/Tokyo]", "9:0:0 [America/New_York]", "9:0:0 [Europe/London]"},
+			BeginTime: "8:0:0 [Asia/Tokyo]",
+			EndTime:   "23:0:0",
+		}
+		require.NoError(t, opts.CheckOpts())
+
+		// The begin time in its own zone is after the end time
+		opts.BeginTime = "23:30:0 [Asia/Tokyo]"
+		opts.EndTime = "22:0:0"
+		require.ErrorIs(t, opts.CheckOpts(), ErrIncorrectBeginEndTimeOrder)
+	})
+	t.Run("dates", func(t *testing.T) {
+		// A date starts at midnight in the location, or at the time of day
+		stamp, err := DateAtTime("2023-2-1", "", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, shanghai).Unix(), stamp)
+		stamp, err = DateAtTime("2023-W05-3", "9:30:0", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 2, 1, 9, 30, 0, 0, shanghai).Unix(), stamp)
+
+		// A date with its own zone starts in that zone
+		stamp, err = DateAtTime("2023-2-1 [America/New_York]", "", shanghai)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, newYork).Unix(), stamp)
+
+		// Only a date is accepted
+		_, err = DateAtTime("2023-2-1 9:0:0", "", shanghai)
+		require.Equal(t, ErrTimeParsion, err)
+	})
+}
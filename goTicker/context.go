@@ -0,0 +1,96 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+/*
+WindowContext returns a copy of parent which is cancelled when the current window of the ticker closes at EndStamp,
+so a long-running job started by a signal stops together with the window.
+The window is read when WindowContext is called, so a context taken after ReNew follows the renewed window.
+*/
+func (receive *GoTicker) WindowContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc, err error) {
+	// Hold the lock while the window is being read
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Make sure the ticker has a window
+	if receive.EndStamp == 0 {
+		err = tickerBase.ErrNotNewedTicker
+		return
+	}
+
+	// Cancel the context at the end of the window
+	var deadline time.Time
+	deadline, err = receive.deadline(receive.EndStamp)
+	if err != nil {
+		return
+	}
+	ctx, cancel = context.WithDeadline(parent, deadline)
+
+	// Return the ctx, cancel and err values
+	return
+}
+
+/*
+NextPointContext returns a copy of parent which is cancelled at the next scheduled point of the ticker,
+so a job started by a signal stops before the next signal arrives.
+It returns the error of the wait list when no point is left in the current window.
+*/
+func (receive *GoTicker) NextPointContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc, err error) {
+	// Hold the lock while the wait list is being calculated
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Make sure the ticker has a window
+	if receive.EndStamp == 0 {
+		err = tickerBase.ErrNotNewedTicker
+		return
+	}
+
+	// Look for the first point after now
+	now := receive.Now().Unix()
+	// (the error only reports an inactive list, which is returned when no point is found)
+	waitList, listErr := receive.mergeSortedBaseListAndRepeatAt(2, now)
+	for i := 0; i < len(waitList); i++ {
+		if waitList[i] > now {
+			// Cancel the context at the point
+			var deadline time.Time
+			deadline, err = receive.deadline(waitList[i])
+			if err != nil {
+				return
+			}
+			ctx, cancel = context.WithDeadline(parent, deadline)
+			return
+		}
+	}
+
+	// No point is left in the current window
+	err = listErr
+	if err == nil {
+		err = tickerBase.ErrInactiveBaseListAndRepeatList
+	}
+
+	// Return the ctx, cancel and err values
+	return
+}
+
+// deadline converts the time stamp into a time in the location of the ticker.
+// The caller must hold the lock.
+func (receive *GoTicker) deadline(timeStamp int64) (output time.Time, err error) {
+	// Make sure the location is loaded
+	if receive.BaseLocation == nil {
+		err = receive.reloadLocation()
+		if err != nil {
+			return
+		}
+	}
+
+	// Convert the time stamp
+	output = time.Unix(timeStamp, 0).In(receive.BaseLocation)
+
+	// Return the output and err values
+	return
+}
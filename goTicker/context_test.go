@@ -0,0 +1,83 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_WindowContext checks that the contexts are cancelled at the end of the window and at the next point.
+func Test_Check_WindowContext(t *testing.T) {
+	t.Run("not newed ticker", func(t *testing.T) {
+		gt := &GoTicker{}
+		_, _, err := gt.WindowContext(context.Background())
+		require.Equal(t, tickerBase.ErrNotNewedTicker, err)
+		_, _, err = gt.NextPointContext(context.Background())
+		require.Equal(t, tickerBase.ErrNotNewedTicker, err)
+	})
+	t.Run("deadlines follow the window", func(t *testing.T) {
+		// Get the current time
+		now := time.Now()
+
+		// Create a new GoTicker and set its properties
+		gt := &GoTicker{
+			BaseStamp: now.Unix(),
+			BaseList: []int64{
+				now.Add(1 * time.Second).Unix(),
+			},
+			BeginStamp: now.Add(0 * time.Second).Unix(),
+			EndStamp:   now.Add(2 * time.Second).Unix(),
+			Opts: tickerBase.Opts{
+				Duration: time.Hour,
+			},
+		}
+
+		// The window context ends at the end stamp
+		windowCtx, windowCancel, err := gt.WindowContext(context.Background())
+		require.NoError(t, err)
+		defer windowCancel()
+		deadline, ok := windowCtx.Deadline()
+		require.True(t, ok)
+		require.Equal(t, gt.EndStamp, deadline.Unix())
+
+		// The next point context ends at the base list element
+		pointCtx, pointCancel, err := gt.NextPointContext(context.Background())
+		require.NoError(t, err)
+		defer pointCancel()
+		deadline, ok = pointCtx.Deadline()
+		require.True(t, ok)
+		require.Equal(t, gt.BaseList[0], deadline.Unix())
+
+		// Both contexts are cancelled in order
+		<-pointCtx.Done()
+		require.NoError(t, windowCtx.Err())
+		<-windowCtx.Done()
+		require.Equal(t, context.DeadlineExceeded, windowCtx.Err())
+
+		// No point is left once the window has closed
+		_, _, err = gt.NextPointContext(context.Background())
+		require.Error(t, err)
+	})
+	t.Run("parent cancellation", func(t *testing.T) {
+		// Get the current time
+		now := time.Now()
+
+		// Create a new GoTicker with a long window
+		gt := &GoTicker{
+			BaseStamp:  now.Unix(),
+			BeginStamp: now.Unix(),
+			EndStamp:   now.Add(time.Hour).Unix(),
+		}
+
+		// Cancelling the parent cancels the window context
+		parent, parentCancel := context.WithCancel(context.Background())
+		ctx, cancel, err := gt.WindowContext(parent)
+		require.NoError(t, err)
+		defer cancel()
+		parentCancel()
+		<-ctx.Done()
+		require.Equal(t, context.Canceled, ctx.Err())
+	})
+}
@@ -0,0 +1,57 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+/*
+sendElapsedSignals sends a signal every Duration of elapsed time in the computer mode,
+measured on the monotonic clock from its start, so stepping the clock or changing the time zone does not move the points.
+When the receivers hold the ticker up for a whole Duration or more, the missed points are reported as one delay signal.
+It returns nil when the options are updated, so SendSignals can start again with them.
+*/
+func (receive *GoTicker) sendElapsedSignals(ctx context.Context) (err error) {
+	// Read the duration of the new options
+	receive.Mu.Lock()
+	duration := receive.Opts.Duration
+	receive.Mu.Unlock()
+	receive.Status.Store(StatusProducedWaitListBefore)
+
+	// Plan every point from the start
+	start := receive.Now()
+	for k := int64(1); ; k++ {
+		planned := start.Add(time.Duration(k) * duration)
+
+		// Wait until the point is reached
+		timer := receive.newTimer(planned.Sub(receive.Now()))
+		var reloaded bool
+		reloaded, err = receive.waitForTimer(ctx, timer)
+		timer.Stop()
+		if err != nil || reloaded {
+			return
+		}
+
+		// Send an on-time signal stamped with the planned time
+		late := receive.Now().Sub(planned)
+		signal := tickerBase.TickerSignal{
+			SignalStatus: tickerBase.SignalOnTime,
+			SerialNumber: receive.serialNumber(planned.Unix()),
+			TimeStamp:    planned.Unix(),
+			Drift:        late,
+			Source:       tickerBase.SourceRepeat,
+		}
+		// Send a delay signal instead if whole points were missed, and skip them
+		if late >= duration {
+			signal.SignalStatus = tickerBase.SignalDelay
+			signal.DelaySeconds = int64(late / time.Second)
+			signal.Drift = 0
+			k += int64(late / duration)
+		}
+		err = receive.sendSignal(ctx, signal)
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test_Check_Mode checks that New validates the mode, and that the computer mode fires by elapsed time and ignores clock jumps.
+func Test_Check_Mode(t *testing.T) {
+	// Options of a ticker for the whole day
+	opts := tickerBase.Opts{
+		BaseTime:  "0:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  300 * time.Millisecond,
+		BeginTime: "0:0:0",
+		EndTime:   "23:59:59",
+	}
+
+	t.Run("new validates the mode", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.Equal(t, tickerBase.HumanMode, gt.Mode)
+
+		invalid := opts
+		invalid.Mode = tickerBase.CommputerMode + 1
+		_, err = New(invalid, tickerBase.OffOpts{})
+		require.ErrorIs(t, err, tickerBase.ErrUnSupportedMode)
+	})
+	t.Run("computer mode fires by elapsed time", func(t *testing.T) {
+		// Create a ticker in the computer mode, which can not be attached to an engine
+		computer := opts
+		computer.Mode = tickerBase.CommputerMode
+		gt, err := New(computer, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// Simulate the wall clock of the ticker with an offset which can be stepped
+		var offset atomic.Int64
+		gt.WallClock = func() time.Time {
+			return time.Now().Add(time.Duration(offset.Load()))
+		}
+		require.Equal(t, tickerBase.CommputerMode, gt.Mode)
+		require.Equal(t, tickerBase.ErrUnSupportedMode, NewEngine().Attach(gt))
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
+		start := time.Now()
+		go func() {
+			errChan <- gt.SendSignals(ctx)
+		}()
+
+		// The signals arrive every duration, even when the wall clock is stepped
+		for i := 1; i <= 5; i++ {
+			if i == 2 {
+				offset.Store(int64(time.Hour))
+			}
+			signal := <-gt.SignalChan
+			require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+			require.InDelta(t, float64(time.Duration(i)*computer.Duration), float64(time.Since(start)), float64(100*time.Millisecond))
+			require.Less(t, signal.Drift, 100*time.Millisecond)
+		}
+
+		// Stop the ticker
+		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+	})
+}
+
+/*
+Test_Check_Mode_DST checks both modes across the start of daylight saving time in New York on 2023-3-12,
+where the human mode keeps firing at 9 o'clock of the local clock and rolls over at local midnight,
+and the computer mode fires every 24 hours of elapsed time, which is an hour later on the local clock after the change.
+*/
+func Test_Check_Mode_DST(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	// run sends the signals of a ticker from the start to the end on the mocked time, and returns the on-time points
+	run := func(mode uint, from, to time.Time) (gt *GoTicker, points []time.Time) {
+		gt, err := New(tickerBase.Opts{
+			BaseTime:   "9:0:0",
+			Location:   "America/New_York",
+			Duration:   24 * time.Hour,
+			BeginTime:  "0:0:0",
+			EndTime:    "23:59:59",
+			BufferSize: 16,
+			Mode:       mode,
+		}, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.NoError(t, gt.MockTime(from))
+
+		// Start a new goroutine to send signals from the ticker, and move the mocked time to the end
+		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- gt.SendSignals(ctx)
+		}()
+		require.True(t, waitUntil(func() bool {
+			return atomic.LoadUint32(&gt.Active32) == 1
+		}))
+		advanceMockTo(t, gt, to)
+		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+
+		// Collect the points fired on time
+		for len(gt.SignalChan) > 0 {
+			signal := <-gt.SignalChan
+			if signal.SignalStatus == tickerBase.SignalOnTime {
+				points = append(points, time.Unix(signal.TimeStamp, 0).In(location))
+			}
+		}
+		return
+	}
+
+	t.Run("human mode follows the local clock", func(t *testing.T) {
+		gt, points := run(tickerBase.HumanMode, at("2023-3-11", "7:30:0"), at("2023-3-13", "12:0:0"))
+		require.Equal(t, []time.Time{
+			at("2023-3-11", "9:0:0"),
+			at("2023-3-12", "9:0:0"),
+			at("2023-3-13", "9:0:0"),
+		}, points)
+
+		// The day of the change only has 23 hours, and the ticker rolled over at every local midnight
+		require.Equal(t, 23*time.Hour, points[1].Sub(points[0]))
+		require.Equal(t, 24*time.Hour, points[2].Sub(points[1]))
+		require.Equal(t, "2023-3-13", gt.NowDate)
+	})
+	t.Run("computer mode follows the elapsed time", func(t *testing.T) {
+		_, points := run(tickerBase.CommputerMode, at("2023-3-11", "7:30:0"), at("2023-3-13", "12:0:0"))
+		require.Equal(t, []time.Time{
+			at("2023-3-12", "8:30:0"),
+			at("2023-3-13", "8:30:0"),
+		}, points)
+
+		// Every point is 24 hours after the one before, whatever the local clock says
+		require.Equal(t, 24*time.Hour, points[0].Sub(at("2023-3-11", "7:30:0")))
+		require.Equal(t, 24*time.Hour, points[1].Sub(points[0]))
+	})
+}
@@ -0,0 +1,407 @@
+package goTicker
+
+import (
+	"container/heap"
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+Engine drives many GoTickers with one goroutine and one timer, instead of a goroutine and a timer per point for every ticker.
+The next point of every attached ticker is kept in a min-heap, and the timer is always set for the earliest one.
+An attached ticker takes the place of its SendSignals, so SendSignals returns immediately while the ticker is attached.
+The engine never waits for a receiver: OverflowBlock outlets get a signal only if they can take it right now,
+and a signal they can not take is counted in DroppedSignals, while the other overflow policies work as usual.
+Opts.Precision is ignored, because spinning for one ticker would hold up all the others,
+and only tickers in HumanMode can be attached, because the heap is ordered by clock times,
+so UpdateOpts rejects the computer mode for an attached ticker with ErrUnSupportedMode.
+Every ticker is fired on its own clock, so a ticker on a mocked time fires once MockTime or AdvanceMockTime moves it past a point.
+TriggerNow, UpdateOpts and ReloadLocation of an attached ticker are served by the engine as they are by SendSignals,
+and the serial handlers are called and the signals are sent after the engine lock is released.
+*/
+type Engine struct {
+	mu       sync.Mutex
+	entries  engineHeap
+	tickers  map[*GoTicker]*engineEntry
+	requests []engineRequest // the requests of the attached tickers which are not served yet
+	wake     chan struct{}   // wakes Run up when the earliest point has changed
+}
+
+// requests of an attached ticker to its engine
+const (
+	engineTrigger uint = iota + 1 // send a manual signal, for TriggerNow
+	engineReload                  // report the updated options and plan the points again, for UpdateOpts
+	engineReplan                  // renew the time stamps and plan the points again, for ReloadLocation, MockTime and UnmockTime
+	engineWake                    // look at the due points again, for AdvanceMockTime
+)
+
+// engineRequest is a request of an attached ticker.
+type engineRequest struct {
+	entry   *engineEntry
+	request uint
+}
+
+// engineSignal is a signal for a ticker, which is sent once the engine lock is released.
+type engineSignal struct {
+	ticker *GoTicker
+	signal tickerBase.TickerSignal
+	serial bool // generate the serial number of the time stamp before sending
+}
+
+// engineEntry is an attached ticker with its next point.
+type engineEntry struct {
+	ticker   *GoTicker
+	iterator *PointIterator
+	point    int64 // Unix time of the next point, or of the next date when rollover is true
+	fired    int64 // Unix time of the last point fired, which a restart must not fire again
+	source   uint  // the source of the next point
+	rollover bool  // the points of the current date are exhausted, so the ticker renews at point
+	index    int   // the position in the heap
+}
+
+// engineHeap is a min-heap of entries ordered by their next point.
+type engineHeap []*engineEntry
+
+func (h engineHeap) Len() int           { return len(h) }
+func (h engineHeap) Less(i, j int) bool { return h[i].point < h[j].point }
+func (h engineHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *engineHeap) Push(x any) {
+	entry := x.(*engineEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+func (h *engineHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// NewEngine creates an engine without any ticker, which starts firing once Run is called.
+func NewEngine() (engine *Engine) {
+	engine = &Engine{
+		tickers: make(map[*GoTicker]*engineEntry),
+		wake:    make(chan struct{}, 1),
+	}
+
+	// Return the engine value
+	return
+}
+
+// Attach hands the ticker over to the engine, starting from its points after now.
+// It returns ErrAlreadyActive if SendSignals is running for the ticker or the ticker is attached already.
+func (receive *Engine) Attach(ticker *GoTicker) (err error) {
+	// The engine only schedules clock times
+	ticker.Mu.Lock()
+	mode := ticker.Mode
+	ticker.Mu.Unlock()
+	if mode == tickerBase.CommputerMode {
+		err = tickerBase.ErrUnSupportedMode
+		return
+	}
+
+	// Take the place of SendSignals
+	if !atomic.CompareAndSwapUint32(&ticker.Active32, 0, 1) {
+		err = tickerBase.ErrAlreadyActive
+		return
+	}
+
+	// Add the ticker to the heap
+	entry := &engineEntry{ticker: ticker}
+	var signals []engineSignal
+	entry.next(ticker.Now().Unix(), &signals)
+	receive.mu.Lock()
+	receive.tickers[ticker] = entry
+	heap.Push(&receive.entries, entry)
+	receive.mu.Unlock()
+	send(signals)
+
+	// Take the trigger and reload requests of the ticker
+	ticker.Mu.Lock()
+	ticker.EngineRequest = func(request uint) {
+		receive.request(entry, request)
+	}
+	ticker.Mu.Unlock()
+	receive.notify()
+
+	// Return err value
+	return
+}
+
+// Detach takes the ticker back from the engine, after which SendSignals can run for it again.
+func (receive *Engine) Detach(ticker *GoTicker) (err error) {
+	// Remove the ticker from the heap
+	receive.mu.Lock()
+	entry, ok := receive.tickers[ticker]
+	if ok {
+		delete(receive.tickers, ticker)
+		heap.Remove(&receive.entries, entry.index)
+	}
+	receive.mu.Unlock()
+	if !ok {
+		err = tickerBase.ErrNotAttached
+		return
+	}
+
+	// Give the requests back to SendSignals, and allow it to run again
+	ticker.Mu.Lock()
+	ticker.EngineRequest = nil
+	ticker.Mu.Unlock()
+	atomic.StoreUint32(&ticker.Active32, 0)
+
+	// Return err value
+	return
+}
+
+// Refresh restarts the points of an attached ticker from now, which UpdateOpts and ReloadLocation already do.
+func (receive *Engine) Refresh(ticker *GoTicker) (err error) {
+	// Recalculate the next point of the ticker
+	var signals []engineSignal
+	receive.mu.Lock()
+	entry, ok := receive.tickers[ticker]
+	if ok {
+		entry.next(ticker.Now().Unix(), &signals)
+		heap.Fix(&receive.entries, entry.index)
+	}
+	receive.mu.Unlock()
+	if !ok {
+		err = tickerBase.ErrNotAttached
+		return
+	}
+	send(signals)
+	receive.notify()
+
+	// Return err value
+	return
+}
+
+// Run fires the points of the attached tickers until the context is done, and then returns ErrUserInterrupted.
+// Only one Run should be called for an engine.
+func (receive *Engine) Run(ctx context.Context) (err error) {
+	// Create the only timer of the engine
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		// Fire every point which is due, and find the earliest point left
+		wait := receive.fire()
+
+		// Set the timer for the earliest point
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		// Wait for the timer, a change of the tickers or the end of the context
+		select {
+		case <-timer.C:
+		case <-receive.wake:
+		case <-ctx.Done():
+			err = tickerBase.ErrUserInterrupted
+			return
+		}
+	}
+}
+
+// fire serves the requests of the tickers and sends the signals of every point which is due,
+// and returns how long to wait for the earliest point left.
+func (receive *Engine) fire() (wait time.Duration) {
+	var signals []engineSignal
+	receive.mu.Lock()
+
+	// Serve the requests of the tickers which are still attached
+	requests := receive.requests
+	receive.requests = nil
+	for i := 0; i < len(requests); i++ {
+		entry := requests[i].entry
+		if receive.tickers[entry.ticker] != entry {
+			continue
+		}
+		entry.serve(requests[i].request, entry.ticker.Now().Unix(), &signals)
+		heap.Fix(&receive.entries, entry.index)
+	}
+
+	// Pop the entries in order until the earliest one is in the future on the clock of its ticker
+	for len(receive.entries) > 0 {
+		entry := receive.entries[0]
+		now := entry.ticker.Now().Unix()
+		if entry.point > now {
+			break
+		}
+		entry.fire(now, &signals)
+		heap.Fix(&receive.entries, 0)
+	}
+
+	// Wait for the earliest point, or for a long time if there is none or its ticker waits for its mocked time to move
+	wait = time.Hour
+	if len(receive.entries) > 0 && receive.entries[0].ticker.MockNano.Load() == 0 {
+		wait = time.Unix(receive.entries[0].point, 0).Sub(receive.entries[0].ticker.Now())
+	}
+	receive.mu.Unlock()
+
+	// Send the signals without the engine lock, so the serial handlers and the receivers may use the engine
+	send(signals)
+
+	// Return the wait value
+	return
+}
+
+// request queues a request of an attached ticker and wakes Run up.
+func (receive *Engine) request(entry *engineEntry, request uint) {
+	receive.mu.Lock()
+	receive.requests = append(receive.requests, engineRequest{entry: entry, request: request})
+	receive.mu.Unlock()
+	receive.notify()
+}
+
+// send generates the serial numbers of the signals and sends them to the outlets of their tickers, in order.
+func send(signals []engineSignal) {
+	for i := 0; i < len(signals); i++ {
+		if signals[i].serial {
+			signals[i].signal.SerialNumber = signals[i].ticker.serialNumber(signals[i].signal.TimeStamp)
+		}
+		signals[i].ticker.deliver(signals[i].signal)
+	}
+}
+
+// notify wakes Run up without blocking.
+func (receive *Engine) notify() {
+	select {
+	case receive.wake <- struct{}{}:
+	default:
+	}
+}
+
+// fire queues the signal of the due point of the entry, or renews the ticker at the next date,
+// and then moves the entry on to its next point.
+func (receive *engineEntry) fire(now int64, signals *[]engineSignal) {
+	gt := receive.ticker
+
+	// Renew the ticker at the next date
+	if receive.rollover {
+		gt.Mu.Lock()
+		err := gt.rollOver()
+		gt.Mu.Unlock()
+		if err == nil {
+			gt.Status.Store(StatusRecover)
+		}
+		receive.next(now, signals)
+		return
+	}
+
+	// Queue an on-time signal, or a signal with the delay time if the engine is late
+	signal := tickerBase.TickerSignal{
+		SignalStatus: tickerBase.SignalOnTime,
+		TimeStamp:    receive.point,
+		Source:       receive.source,
+	}
+	if now > receive.point {
+		signal.SignalStatus = tickerBase.SignalDelay
+		signal.DelaySeconds = now - receive.point
+	} else {
+		signal.Drift = gt.Now().Sub(time.Unix(receive.point, 0))
+	}
+	*signals = append(*signals, engineSignal{ticker: gt, signal: signal, serial: true})
+	receive.fired = receive.point
+
+	// Move on to the next point
+	receive.advance(now, signals)
+}
+
+// serve queues the signal of a request of the ticker, and plans its points again if the request asks for it.
+func (receive *engineEntry) serve(request uint, now int64, signals *[]engineSignal) {
+	gt := receive.ticker
+	switch request {
+	case engineTrigger:
+		// Send a manual signal stamped with the current time
+		*signals = append(*signals, engineSignal{ticker: gt, signal: tickerBase.TickerSignal{
+			SignalStatus: tickerBase.SignalManual,
+			TimeStamp:    now,
+		}, serial: true})
+	case engineReload:
+		// Report the updated options, which are already converted for the current date
+		*signals = append(*signals, engineSignal{ticker: gt, signal: tickerBase.TickerSignal{
+			SignalStatus: tickerBase.SignalOptsUpdated,
+		}})
+		receive.next(now, signals)
+	case engineReplan:
+		// Convert the time stamps in the reloaded location
+		gt.Mu.Lock()
+		_ = gt.rollOver()
+		gt.Mu.Unlock()
+		receive.next(now, signals)
+	case engineWake:
+		// The due points are fired by the caller on the moved clock
+	}
+}
+
+// next restarts the points of the entry from the given Unix time.
+func (receive *engineEntry) next(from int64, signals *[]engineSignal) {
+	receive.iterator = receive.ticker.replanPoints(from, receive.fired)
+	receive.ticker.Status.Store(StatusProducedWaitListBefore)
+	receive.advance(from, signals)
+}
+
+// advance takes the next point from the iterator, or waits for the next date when there is none.
+func (receive *engineEntry) advance(now int64, signals *[]engineSignal) {
+	// Take the next point
+	var ok bool
+	receive.point, ok = receive.iterator.Next()
+	receive.source = receive.iterator.Source()
+	receive.rollover = !ok
+	if ok {
+		return
+	}
+
+	// Tell the receivers to wait until the next date
+	*signals = append(*signals, engineSignal{ticker: receive.ticker, signal: tickerBase.TickerSignal{
+		SignalStatus: tickerBase.SignalWaitForTomorrow,
+	}})
+	receive.ticker.Status.Store(StatusWaitForTomorrow)
+
+	// Renew at the start of the next date in the location of the ticker
+	receive.ticker.Mu.Lock()
+	location := receive.ticker.BaseLocation
+	receive.ticker.Mu.Unlock()
+	if location == nil {
+		location = time.Local
+	}
+	today := time.Unix(now, 0).In(location)
+	receive.point = time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, location).Unix()
+}
+
+// deliver sends a signal to every outlet of the ticker without waiting for any receiver.
+func (receive *GoTicker) deliver(signal tickerBase.TickerSignal) {
+	// Collect the outlets under the lock
+	receive.Mu.Lock()
+	outlets := receive.outlets()
+	receive.Mu.Unlock()
+
+	// Send the signal to every outlet
+	for i := 0; i < len(outlets); i++ {
+		if outlets[i].overflowPolicy == tickerBase.OverflowDropNewest ||
+			outlets[i].overflowPolicy == tickerBase.OverflowDropOldest ||
+			outlets[i].overflowPolicy == tickerBase.OverflowCoalesce {
+			// These policies never wait
+			_ = outlets[i].send(context.Background(), 0, signal)
+			continue
+		}
+		select {
+		case outlets[i].signalChan <- signal:
+		default:
+			// Nobody can take the signal right now, so drop it
+			outlets[i].droppedSignals.Add(1)
+		}
+	}
+}
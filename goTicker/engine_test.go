@@ -0,0 +1,211 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Engine checks that an engine fires the points of several tickers with one goroutine,
+// and that attached tickers can not be driven twice.
+func Test_Check_Engine(t *testing.T) {
+	// Get the current time
+	now := time.Now()
+
+	// newTicker creates a ticker with a buffered channel which fires at the given offsets
+	newTicker := func(offsets ...time.Duration) (gt *GoTicker) {
+		gt = &GoTicker{
+			BaseStamp:  now.Unix(),
+			BeginStamp: now.Unix(),
+			EndStamp:   now.Add(4 * time.Second).Unix(),
+			Opts: tickerBase.Opts{
+				Duration:       time.Nanosecond,
+				OverflowPolicy: tickerBase.OverflowDropNewest,
+			},
+		}
+		for i := 0; i < len(offsets); i++ {
+			gt.BaseList = append(gt.BaseList, now.Add(offsets[i]).Unix())
+		}
+		gt.SignalChan = make(chan tickerBase.TickerSignal, 4)
+		err := gt.ReloadLocation()
+		require.NoError(t, err)
+		err = gt.UpdateNowDateOrMock("")
+		require.NoError(t, err)
+		return
+	}
+	first := newTicker(1*time.Second, 3*time.Second)
+	second := newTicker(2 * time.Second)
+
+	// Attach both tickers to one engine
+	engine := NewEngine()
+	require.NoError(t, engine.Attach(first))
+	require.NoError(t, engine.Attach(second))
+	require.Equal(t, tickerBase.ErrAlreadyActive, engine.Attach(first))
+
+	// SendSignals does not run for an attached ticker
+	require.NoError(t, first.SendSignals(context.Background()))
+
+	// Run the engine
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- engine.Run(ctx)
+	}()
+
+	t.Run("fire the points of every ticker", func(t *testing.T) {
+		for _, point := range []time.Duration{1 * time.Second, 3 * time.Second} {
+			signal := <-first.SignalChan
+			require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+			require.Equal(t, now.Add(point).Unix(), signal.TimeStamp)
+		}
+		signal := <-second.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.Equal(t, now.Add(2*time.Second).Unix(), signal.TimeStamp)
+
+		// No point is left for today
+		require.Equal(t, tickerBase.SignalWaitForTomorrow, (<-first.SignalChan).SignalStatus)
+		require.Equal(t, tickerBase.SignalWaitForTomorrow, (<-second.SignalChan).SignalStatus)
+	})
+	t.Run("detach", func(t *testing.T) {
+		require.NoError(t, engine.Detach(first))
+		require.Equal(t, tickerBase.ErrNotAttached, engine.Detach(first))
+		require.Equal(t, tickerBase.ErrNotAttached, engine.Refresh(first))
+		require.NoError(t, engine.Refresh(second))
+	})
+
+	// Stop the engine
+	cancel()
+	require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+}
+
+// Test_Check_Engine_Requests checks that an engine serves TriggerNow and UpdateOpts of an attached ticker,
+// and that the serial handlers are called outside the engine lock.
+func Test_Check_Engine_Requests(t *testing.T) {
+	// Options of a ticker which fires at midnight only
+	opts := tickerBase.Opts{
+		BaseTime:   "0:0:0",
+		Location:   tickerBase.DefaultTimeZone,
+		Duration:   24 * time.Hour,
+		BeginTime:  "0:0:0",
+		EndTime:    "23:59:59",
+		BufferSize: 8,
+	}
+	gt, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	other, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+
+	// The serial handler uses the engine, which deadlocks if the engine lock is held
+	engine := NewEngine()
+	gt.SerialHandler = func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+		require.NoError(t, engine.Refresh(other))
+		*serialBase++
+		newSerial = *serialBase
+		return
+	}
+
+	// Attach the tickers and run the engine
+	require.NoError(t, engine.Attach(gt))
+	require.NoError(t, engine.Attach(other))
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- engine.Run(ctx)
+	}()
+
+	// The midnight of today is passed, and no point is left for today
+	signal := <-gt.SignalChan
+	require.Equal(t, tickerBase.SignalDelay, signal.SignalStatus)
+	require.Equal(t, uint64(1), signal.SerialNumber)
+	require.Equal(t, tickerBase.SignalWaitForTomorrow, (<-gt.SignalChan).SignalStatus)
+
+	t.Run("trigger now", func(t *testing.T) {
+		require.NoError(t, gt.TriggerNow())
+		signal := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalManual, signal.SignalStatus)
+		require.Equal(t, uint64(2), signal.SerialNumber)
+	})
+	t.Run("update options without refresh", func(t *testing.T) {
+		// Move the base time two seconds after now
+		point := time.Now().Add(2 * time.Second).In(gt.BaseLocation)
+		updated := opts
+		updated.BaseTime = point.Format("15:04:05")
+		require.NoError(t, gt.UpdateOpts(updated, tickerBase.OffOpts{}))
+
+		// The engine reports the change and fires at the new point
+		require.Equal(t, tickerBase.SignalOptsUpdated, (<-gt.SignalChan).SignalStatus)
+		signal := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.Equal(t, point.Unix(), signal.TimeStamp)
+		require.Equal(t, uint64(3), signal.SerialNumber)
+	})
+	t.Run("detached tickers go back to their channels", func(t *testing.T) {
+		require.NoError(t, engine.Detach(gt))
+		gt.Mu.Lock()
+		require.Nil(t, gt.EngineRequest)
+		gt.Mu.Unlock()
+		require.NoError(t, gt.TriggerNow())
+		require.Len(t, gt.TriggerChan, 1)
+	})
+
+	// Stop the engine
+	cancel()
+	require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+}
+
+// Test_Check_Engine_Mock checks that an engine fires a ticker on its mocked time,
+// and keeps an attached ticker in the human mode.
+func Test_Check_Engine_Mock(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	// Create a ticker which fires every hour on the mocked time
+	opts := tickerBase.Opts{
+		BaseTime:   "12:0:0",
+		Location:   tickerBase.DefaultTimeZone,
+		Duration:   time.Hour,
+		BeginTime:  "11:0:0",
+		EndTime:    "14:0:0",
+		BufferSize: 8,
+	}
+	gt, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(at("2023-1-31", "11:30:0")))
+
+	// Attach the ticker and run the engine
+	engine := NewEngine()
+	require.NoError(t, engine.Attach(gt))
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- engine.Run(ctx)
+	}()
+
+	t.Run("fire on the mocked time", func(t *testing.T) {
+		// Nothing is due until the mocked time reaches the point
+		select {
+		case signal := <-gt.SignalChan:
+			t.Fatalf("a signal arrived before the mocked point: %v", signal)
+		case <-time.After(100 * time.Millisecond):
+		}
+		require.NoError(t, gt.AdvanceMockTime(30*time.Minute))
+		signal := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.Equal(t, at("2023-1-31", "12:0:0").Unix(), signal.TimeStamp)
+		require.Equal(t, time.Duration(0), signal.Drift)
+	})
+	t.Run("reject the computer mode", func(t *testing.T) {
+		computer := opts
+		computer.Mode = tickerBase.CommputerMode
+		require.ErrorIs(t, gt.UpdateOpts(computer, tickerBase.OffOpts{}), tickerBase.ErrUnSupportedMode)
+		require.Equal(t, tickerBase.HumanMode, gt.Snapshot().Mode)
+	})
+
+	// Stop the engine
+	cancel()
+	require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+}
@@ -3,6 +3,8 @@ package goTicker
 import (
 	"context"
 	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"runtime"
+	"sort"
 	"sync/atomic"
 	"time"
 )
@@ -18,24 +20,36 @@ const (
 	StatusDead                                     // 7: Dead status
 )
 
-var mockDateStr string
+// SnapshotQuantity is the number of upcoming points included in a snapshot.
+const SnapshotQuantity = 10
+
+// clockCheckInterval is how often a wait compares the wall clock with the monotonic clock.
+const clockCheckInterval = time.Second
+
+// clockJumpThreshold is the difference between the wall clock and the monotonic clock which counts as a jump.
+const clockJumpThreshold = time.Second
+
+// coarseMargin is how much earlier than Opts.Precision the coarse timer of the precision mode fires,
+// to absorb the lateness of the timer itself.
+const coarseMargin = 2 * time.Millisecond
 
 type GoTicker tickerBase.Base
 
 // updateNowDateOrMockAndReloadLocation updates ticker parameters and reloads location information if necessary.
-func (receive *GoTicker) updateNowDateOrMockAndReloadLocation(mockDateStr string) (err error) {
+// The caller must hold the lock or be the only one with access to the ticker.
+func (receive *GoTicker) updateNowDateOrMockAndReloadLocation(input string) (err error) {
 	// Update nowDate with the current date or mocked date if it's set
-	err = receive.UpdateNowDateOrMock(mockDateStr)
+	err = receive.updateNowDateOrMock(input)
 
 	// If base location is not set, reload location and update nowDate again
 	if err == tickerBase.ErrNoBaseLocation {
 		// Reload location
-		err = receive.ReloadLocation()
+		err = receive.reloadLocation()
 		if err != nil {
 			return
 		}
 		// Update nowDate again
-		err = receive.UpdateNowDateOrMock(mockDateStr)
+		err = receive.updateNowDateOrMock(input)
 		if err != nil {
 			return
 		}
@@ -47,6 +61,8 @@ func (receive *GoTicker) updateNowDateOrMockAndReloadLocation(mockDateStr string
 
 // New is a function named New, which takes two arguments:
 // Opts and OffOpts, representing the options for the ticker and the off options, respectively.
+// When Opts.Location is empty and the default location falls back to UTC, the ticker runs in UTC without an error,
+// like CheckOpts and UpdateOpts accept the options then, and LocationFallback reports it.
 func New(opts tickerBase.Opts, offOpts tickerBase.OffOpts) (output *GoTicker, err error) {
 	// Check if the options are valid
 	err = opts.CheckOpts()
@@ -58,130 +74,208 @@ func New(opts tickerBase.Opts, offOpts tickerBase.OffOpts) (output *GoTicker, er
 	output = new(GoTicker)
 	output.Opts = opts
 	output.OffOpts = offOpts
-	output.SignalChan = make(chan tickerBase.TickerSignal)
+	output.Mode = modeOf(opts)
+	output.SignalChan = make(chan tickerBase.TickerSignal, opts.BufferSize)
+	output.TriggerChan = make(chan struct{}, 1)
+	output.ReloadChan = make(chan struct{}, 1)
+	output.MockChan = make(chan struct{}, 1)
 
 	// If base location is not set, try to reload the location and update the nowDate again
-	// ( "receive.UpdateNowDateOrMock(mockDateStr)" may be called twice, which may cause code duplication.
+	// ( "receive.UpdateNowDateOrMock("")" may be called twice, which may cause code duplication.
 	// Extract it into a separate function or variable to reduce duplicated code.)
-	err = output.updateNowDateOrMockAndReloadLocation(mockDateStr)
+	err = output.updateNowDateOrMockAndReloadLocation("")
 	if err != nil {
 		return
 	}
 
-	// Determine the format of the base time and create a corresponding string
-	var baseTimeStr string
-	output.BaseStampType, err = tickerBase.TimeType(opts.BaseTime)
+	// Convert the options into the time stamps of the ticker
+	err = output.loadStamps()
 	if err != nil {
 		return
 	}
 
-	// If the base time is in the TimeFormat, concatenate the current date and time
-	if output.BaseStampType == tickerBase.TimeFormat {
-		baseTimeStr = output.NowDate + " " + opts.BaseTime
-		// Otherwise, the base time is already in the DatetimeFormat
-	} else if output.BaseStampType == tickerBase.DatetimeFormat {
-		baseTimeStr = opts.BaseTime
+	// Set the SignalStatus value to StatusNewed
+	output.Status.Store(StatusNewed)
+
+	// Return the output and err values
+	return
+}
+
+// modeOf returns the scheduling mode of the options, which is HumanMode unless it is set.
+func modeOf(opts tickerBase.Opts) (mode uint) {
+	mode = opts.Mode
+	if mode == 0 {
+		mode = tickerBase.HumanMode
+	}
+
+	// Return the mode value
+	return
+}
+
+// loadStamps converts the time strings in the options into the time stamps of the ticker,
+// based on NowDate and BaseLocation.
+func (receive *GoTicker) loadStamps() (err error) {
+	// Determine the format of the base time
+	receive.BaseStampType, err = tickerBase.TimeType(receive.Opts.BaseTime)
+	if err != nil {
+		return
 	}
 
 	// Convert the base time string to a Unix timestamp
-	output.BaseStamp, err = tickerBase.TimeValue(baseTimeStr, output.BaseLocation)
+	receive.BaseStamp, err = receive.stampValue(receive.BaseStampType, receive.Opts.BaseTime)
 	if err != nil {
 		return
 	}
 
-	// Determine the format of the base list time (if provided)
-	if len(opts.BaseList) > 0 {
-		output.BaseListType, err = tickerBase.TimeType(opts.BaseList[0])
+	// Determine the format of the base list time (if provided), where a list of dates is in the DatetimeFormat
+	if len(receive.Opts.BaseList) > 0 {
+		receive.BaseListType, err = tickerBase.TimeType(receive.Opts.BaseList[0])
+		if receive.BaseListType == tickerBase.DateFormat {
+			receive.BaseListType = tickerBase.DatetimeFormat
+		}
 	}
 
 	// Convert each element in the base list to a Unix timestamp
-	output.BaseList = make([]int64, 0, len(opts.BaseList))
-	for i := 0; i < len(opts.BaseList); i++ {
+	receive.BaseList = make([]int64, 0, len(receive.Opts.BaseList))
+	for i := 0; i < len(receive.Opts.BaseList); i++ {
 		var element int64
-		// If the base list time is in the TimeFormat, concatenate the current date and time
-		if output.BaseListType == tickerBase.TimeFormat {
-			baseTimeStr = output.NowDate + " " + opts.BaseList[i]
-			element, err = tickerBase.TimeValue(baseTimeStr, output.BaseLocation)
-			if err != nil {
-				return
-			}
-			// Otherwise, the base list time is already in the DatetimeFormat
-		} else if output.BaseListType == tickerBase.DatetimeFormat {
-			element, err = tickerBase.TimeValue(opts.BaseList[i], output.BaseLocation)
+		// The base list time is either in the TimeFormat or in the DatetimeFormat, which may be mixed with dates
+		if receive.BaseListType == tickerBase.TimeFormat || receive.BaseListType == tickerBase.DatetimeFormat {
+			element, err = receive.baseListValue(receive.Opts.BaseList[i])
 			if err != nil {
 				return
 			}
 		}
-		output.BaseList = append(output.BaseList, element)
+		receive.BaseList = append(receive.BaseList, element)
 	}
+	sortStamps(receive.BaseList)
 
-	// Determine the format of the begin time and create a corresponding string
-	var beginTimeStr string
-	output.BeginStampType, err = tickerBase.TimeType(opts.BeginTime)
+	// Determine the format of the begin time
+	receive.BeginStampType, err = tickerBase.TimeType(receive.Opts.BeginTime)
 	if err != nil {
 		return
 	}
 
-	// If the begin time is in the TimeFormat, concatenate the current date and time
-	if output.BeginStampType == tickerBase.TimeFormat {
-		beginTimeStr = output.NowDate + " " + opts.BeginTime
-		// Otherwise, the begin time is already in the DatetimeFormat
-	} else if output.BeginStampType == tickerBase.DatetimeFormat {
-		beginTimeStr = opts.BeginTime
+	// Set the BeginStamp value based on the begin time and BaseLocation
+	receive.BeginStamp, err = receive.stampValue(receive.BeginStampType, receive.Opts.BeginTime)
+	if err != nil {
+		return
 	}
 
-	// Set the BeginStamp value based on the given beginTimeStr and BaseLocation
-	output.BeginStamp, err = tickerBase.TimeValue(beginTimeStr, output.BaseLocation)
+	// Determine the format of the end time
+	receive.EndStampType, err = tickerBase.TimeType(receive.Opts.EndTime)
 	if err != nil {
 		return
 	}
 
-	// Determine the format of the end time and create a corresponding string
-	var endTimeStr string
-	output.EndStampType, err = tickerBase.TimeType(opts.EndTime)
+	// Set the EndStamp value based on the end time and BaseLocation
+	receive.EndStamp, err = receive.stampValue(receive.EndStampType, receive.Opts.EndTime)
 	if err != nil {
 		return
 	}
 
-	// If the end time is in the TimeFormat, concatenate the current date and time
-	if output.EndStampType == tickerBase.TimeFormat {
-		endTimeStr = output.NowDate + " " + opts.EndTime
-		// Otherwise, the end time is already in the DatetimeFormat
-	} else if output.EndStampType == tickerBase.DatetimeFormat {
-		endTimeStr = opts.EndTime
+	// Return err value
+	return
+}
+
+/*
+stampValue converts a time string of the options into its Unix time on NowDate in BaseLocation.
+A time in the TimeFormat is put on NowDate, where a time with its own zone is the occurrence which falls on NowDate,
+and a time in any other format is converted as it is.
+*/
+func (receive *GoTicker) stampValue(tType uint, str string) (stamp int64, err error) {
+	// Put a time of day on the date
+	if tType == tickerBase.TimeFormat {
+		stamp, err = tickerBase.TimeOnDate(receive.NowDate, str, receive.BaseLocation)
+		return
+	}
+
+	// Only a date-time has a value of its own
+	if tType != tickerBase.DatetimeFormat {
+		str = ""
 	}
+	stamp, err = tickerBase.TimeValue(str, receive.BaseLocation)
 
-	// Set the EndStamp value based on the given endTimeStr and BaseLocation
-	output.EndStamp, err = tickerBase.TimeValue(endTimeStr, output.BaseLocation)
+	// Return the stamp and err values
+	return
+}
+
+// baseListValue converts an element of the base list into its Unix time, where a date fires at AllDayTime on that date.
+func (receive *GoTicker) baseListValue(str string) (stamp int64, err error) {
+	// Find the format of the element, which may differ from the first one when dates and date-times are mixed
+	var tType uint
+	tType, err = tickerBase.TimeType(str)
 	if err != nil {
 		return
 	}
 
-	// Set the SignalStatus value to StatusNewed
-	output.Status.Store(StatusNewed)
+	// Put a date at the time of day of the options
+	if tType == tickerBase.DateFormat {
+		stamp, err = tickerBase.DateAtTime(str, receive.Opts.AllDayTime, receive.BaseLocation)
+		return
+	}
+	stamp, err = receive.stampValue(tType, str)
 
-	// Return the output and err values
+	// Return the stamp and err values
 	return
 }
 
+// sortStamps sorts the time stamps of BaseList, whose elements with their own zones may come in any order.
+func sortStamps(stamps []int64) {
+	sort.Slice(stamps, func(i, j int) bool {
+		return stamps[i] < stamps[j]
+	})
+}
+
 // ReNew updates various timestamp-related values based on the current date,
 // and updates the status of the ticker.
 func (receive *GoTicker) ReNew() (err error) {
-	// Check if the ticker has been initialized with New() function
-	if receive.Status.Load() != StatusNewed {
+	// Hold the lock while the time stamps are being updated
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Return err value
+	return receive.reNew()
+}
+
+// reNew is ReNew for callers which already hold the lock.
+func (receive *GoTicker) reNew() (err error) {
+	// Check if the ticker has been initialized with New() function, the mocked time is set after that
+	if status := receive.Status.Load(); status != StatusNewed && status != StatusMockTime {
 		err = tickerBase.ErrNotNewedTicker
 		return
 	}
 
-	// Update nowDate with the current date or mocked date if it's set
-	err = receive.UpdateNowDateOrMock(mockDateStr)
+	// Move on to the current date
+	err = receive.rollOver()
+
+	// Return the output and err values
+	return
+}
+
+// rollOver moves the ticker on to the current date (or the mocked date) and updates its time stamps.
+// Unlike ReNew, it works whatever the status of the ticker is, so a running ticker can cross the day boundary.
+// The caller must hold the lock.
+func (receive *GoTicker) rollOver() (err error) {
+	// Update nowDate with the current date, which is the mocked date if the time is mocked
+	err = receive.updateNowDateOrMock("")
 	if err != tickerBase.ErrNoBaseLocation && err != nil {
 		return
 	}
 
+	// Update the time stamps for the new date
+	err = receive.renewStamps()
+
+	// Return err value
+	return
+}
+
+// renewStamps updates the time stamps in the TimeFormat for NowDate.
+// The caller must hold the lock.
+func (receive *GoTicker) renewStamps() (err error) {
 	// Update baseList if baseListType is TimeFormat
 	if receive.BaseStampType == tickerBase.TimeFormat {
-		receive.BaseStamp, err = tickerBase.TimeValue(receive.NowDate+" "+receive.Opts.BaseTime, receive.BaseLocation)
+		receive.BaseStamp, err = tickerBase.TimeOnDate(receive.NowDate, receive.Opts.BaseTime, receive.BaseLocation)
 		if err != nil {
 			return
 		}
@@ -192,17 +286,18 @@ func (receive *GoTicker) ReNew() (err error) {
 		receive.BaseList = make([]int64, 0, len(receive.Opts.BaseList))
 		for i := 0; i < len(receive.Opts.BaseList); i++ {
 			var baseListElement int64
-			baseListElement, err = tickerBase.TimeValue(receive.NowDate+" "+receive.Opts.BaseList[i], receive.BaseLocation)
+			baseListElement, err = tickerBase.TimeOnDate(receive.NowDate, receive.Opts.BaseList[i], receive.BaseLocation)
 			if err != nil {
 				return
 			}
 			receive.BaseList = append(receive.BaseList, baseListElement)
 		}
+		sortStamps(receive.BaseList)
 	}
 
 	// Update beginStamp if beginStampType is TimeFormat
 	if receive.BeginStampType == tickerBase.TimeFormat {
-		receive.BeginStamp, err = tickerBase.TimeValue(receive.NowDate+" "+receive.Opts.BeginTime, receive.BaseLocation)
+		receive.BeginStamp, err = tickerBase.TimeOnDate(receive.NowDate, receive.Opts.BeginTime, receive.BaseLocation)
 		if err != nil {
 			return
 		}
@@ -210,19 +305,29 @@ func (receive *GoTicker) ReNew() (err error) {
 
 	// Update endStamp if endStampType is TimeFormat
 	if receive.EndStampType == tickerBase.TimeFormat {
-		receive.EndStamp, err = tickerBase.TimeValue(receive.NowDate+" "+receive.Opts.EndTime, receive.BaseLocation)
+		receive.EndStamp, err = tickerBase.TimeOnDate(receive.NowDate, receive.Opts.EndTime, receive.BaseLocation)
 		if err != nil {
 			return
 		}
 	}
 
-	// Return the output and err values
+	// Return err value
 	return
 }
 
 // UpdateNowDateOrMock is a method named UpdateNowDateOrMock that belongs to a struct type GoTicker.
 // This method can take a string as input and update the date and time in the GoTicker struct based on that input.
 func (receive *GoTicker) UpdateNowDateOrMock(input string) (err error) {
+	// Hold the lock while the date is being updated
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Return err value
+	return receive.updateNowDateOrMock(input)
+}
+
+// updateNowDateOrMock is UpdateNowDateOrMock for callers which already hold the lock.
+func (receive *GoTicker) updateNowDateOrMock(input string) (err error) {
 	if input != "" {
 		receive.NowDate = input
 		return
@@ -233,7 +338,7 @@ func (receive *GoTicker) UpdateNowDateOrMock(input string) (err error) {
 		return
 	}
 
-	now := time.Now().In(receive.BaseLocation)
+	now := receive.Now().In(receive.BaseLocation)
 	receive.NowDate = now.Format(tickerBase.DefaultDateFormatStr)
 
 	// Return err value
@@ -242,13 +347,52 @@ func (receive *GoTicker) UpdateNowDateOrMock(input string) (err error) {
 
 // ReloadLocation is a method named ReloadLocation for the Base structure in Golang,
 // which is intended to reload the time zone.
+// An engine driving the ticker plans its points again in the reloaded location.
 func (receive *GoTicker) ReloadLocation() (err error) {
+	// Hold the lock while the location is being reloaded
+	receive.Mu.Lock()
+	err = receive.reloadLocation()
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+
+	// Ask the engine to plan the points again, outside the lock which the engine takes itself
+	if err == nil && request != nil {
+		request(engineReplan)
+	}
+
+	// Return err value
+	return
+}
+
+// LocationFallback reports whether the ticker runs in UTC because Opts.Location is empty
+// and the default location could not be loaded.
+func (receive *GoTicker) LocationFallback() (fallback bool) {
+	// Hold the lock while the location is being read
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// An empty location is only kept when the default location fell back to UTC
+	fallback = receive.Opts.Location == "" && receive.BaseLocation != nil
+
+	// Return the fallback value
+	return
+}
+
+// reloadLocation is ReloadLocation for callers which already hold the lock.
+func (receive *GoTicker) reloadLocation() (err error) {
+	// An empty location is the default location, which falls back to UTC without tzdata,
+	// and the location is kept empty then, so the default is tried again on the next reload
 	if receive.Opts.Location == "" {
-		receive.Opts.Location = tickerBase.DefaultTimeZone
+		var fallbackErr error
+		receive.BaseLocation, fallbackErr = tickerBase.DefaultLocation()
+		if fallbackErr == nil {
+			receive.Opts.Location = tickerBase.DefaultLocationName()
+		}
+		return
 	}
 
-	// reload time location
-	receive.BaseLocation, err = time.LoadLocation(receive.Opts.Location)
+	// reload time location, which may be a fixed offset like "+08:00"
+	receive.BaseLocation, err = tickerBase.LoadLocation(receive.Opts.Location)
 	if err != nil {
 		err = tickerBase.ErrUnSupportedLocation
 		return
@@ -260,85 +404,43 @@ func (receive *GoTicker) ReloadLocation() (err error) {
 
 // mergeSortedBaseListAndRepeat merges a sorted list with a repeated sequence of numbers, producing a new sorted list of a given length.
 func (receive *GoTicker) mergeSortedBaseListAndRepeat(quantity int) (waitList []int64, err error) {
-	// Calculate the headRepeatList and duration of the repeat parameter
-	var headRepeatList, duration int64
-	var previousErr error
-	headRepeatList, duration, previousErr = receive.calculateRepeatParameter()
-
-	// Get the available availableSubBaseList within the specified time range
-	var availableSubBaseList []int64
-	availableSubBaseList, err = receive.availableSubBaseList()
-	if err == nil && previousErr != nil {
-		// Only record the errors that have occurred so far, without immediately returning or reporting them
-		err = previousErr
-	}
-
-	// Create an empty slice to hold the repeated elements
-	availableRepeatList := make([]int64, 0, quantity)
-	// If headRepeatList is not 0 and duration is at least 1,
-	// repeat the headRepeatList element for quantity times with duration as the interval
-	if headRepeatList != 0 && duration >= 1 {
-	LOOP:
-		for i := 0; i < quantity; i++ {
-			availableRepeatList = append(availableRepeatList, headRepeatList)
-			headRepeatList = headRepeatList + duration
-			// [fix] To prevent exceeding the endStamp boundary
-			if headRepeatList > receive.EndStamp {
-				break LOOP
-			}
-		}
-	}
-
-	// Create an empty slice to hold the merged sorted waitList
-	waitList = make([]int64, 0, quantity)
-
-	// Merge the two sorted slices by comparing elements from both slices and adding the smaller one to the waitList
-	i, j := 0, 0
-	for i < len(availableSubBaseList) && j < len(availableRepeatList) {
-		// If the element from the base list is smaller than or equal to the repeated element, add the base element to the waitList
-		if availableSubBaseList[i] == availableRepeatList[j] {
-			// [fix] Make sure the same value does not occur more than once
-			i++
-		} else if availableSubBaseList[i] < availableRepeatList[j] {
-			waitList = append(waitList, availableSubBaseList[i])
-			i++
-			// Otherwise, add the repeated element to the waitList
-		} else if availableSubBaseList[i] > availableRepeatList[j] {
-			waitList = append(waitList, availableRepeatList[j])
-			j++
-		}
+	// Return the waitList and err values
+	return receive.mergeSortedBaseListAndRepeatAt(quantity, receive.Now().Unix())
+}
 
-		// If we have added enough elements to the waitList, return the waitList
-		if len(waitList) >= quantity {
-			return
-		}
-	}
+// mergeSortedBaseListAndRepeatAt is mergeSortedBaseListAndRepeat as seen from the given Unix time instead of the current time.
+// The caller must hold the lock.
+func (receive *GoTicker) mergeSortedBaseListAndRepeatAt(quantity int, now int64) (waitList []int64, err error) {
+	// Only record an inactive repeat list, without immediately returning or reporting it
+	_, _, err = receive.calculateRepeatParameterAt(now)
 
-	// If there are any remaining elements in either slice, add them to the output
-	for k := 0; k < len(availableSubBaseList[i:]); k++ {
-		if availableSubBaseList[i:][k] < receive.EndStamp {
-			waitList = append(waitList, availableSubBaseList[i:][k])
-		}
-	}
-	for k := 0; k < len(availableRepeatList[j:]); k++ {
-		if availableRepeatList[j:][k] < receive.EndStamp {
-			waitList = append(waitList, availableRepeatList[j:][k])
+	// Take the first points of the merged sequence
+	iterator := receive.points(now)
+	waitList = make([]int64, 0, quantity)
+	for len(waitList) < quantity {
+		point, ok := iterator.Next()
+		if !ok {
+			break
 		}
+		waitList = append(waitList, point)
 	}
 
-	// Return the waitList
+	// Return the waitList and err values
 	return
 }
 
 // calculateRepeatParameter calculates the nearest time based on a given duration and
 // returns an error if the duration is less than or equal to 0.
 func (receive *GoTicker) calculateRepeatParameter() (nearest, duration int64, err error) {
+	// Return the output and err values
+	return receive.calculateRepeatParameterAt(receive.Now().Unix())
+}
+
+// calculateRepeatParameterAt is calculateRepeatParameter as seen from the given Unix time instead of the current time.
+func (receive *GoTicker) calculateRepeatParameterAt(now int64) (nearest, duration int64, err error) {
 	// Convert duration to seconds
 	duration = int64(receive.Opts.Duration.Seconds())
 
-	// Get current Unix time
-	now := time.Now().Unix()
-
 	// CalculateWaitList the nearest time based on duration
 	if duration >= 1 {
 		// CalculateWaitList the time distance between current time and base stamp
@@ -359,9 +461,12 @@ func (receive *GoTicker) calculateRepeatParameter() (nearest, duration int64, er
 // availableSubBaseList searches for a suitable base timestamp within a specified time range and
 // returns a list of available sub-base timestamps.
 func (receive *GoTicker) availableSubBaseList() (output []int64, err error) {
-	// Get current Unix time
-	now := time.Now().Unix()
+	// Return the output and err values
+	return receive.availableSubBaseListAt(receive.Now().Unix())
+}
 
+// availableSubBaseListAt is availableSubBaseList as seen from the given Unix time instead of the current time.
+func (receive *GoTicker) availableSubBaseListAt(now int64) (output []int64, err error) {
 	// Loop through the BaseList to find a suitable time
 	for i := 0; i < len(receive.BaseList); i++ {
 		// Check if every BaseList element is within the specified time range and in the future
@@ -380,8 +485,11 @@ func (receive *GoTicker) availableSubBaseList() (output []int64, err error) {
 
 // CalculateWaitList calculates a wait list by merging sorted base lists and a repeat list based on given parameters.
 func (receive *GoTicker) CalculateWaitList(quantity int) (waitList []int64, err error) {
-	// Merge the sorted baseList and repeat parameter into a waitList of specified quantity
+	// Merge the sorted baseList and repeat parameter into a waitList of specified quantity,
+	// holding the lock so that the options can not be swapped in the middle of the calculation
+	receive.Mu.Lock()
 	waitList, err = receive.mergeSortedBaseListAndRepeat(quantity)
+	receive.Mu.Unlock()
 	if len(waitList) == 0 {
 		err = tickerBase.ErrInactiveBaseListAndRepeatList
 	}
@@ -396,7 +504,7 @@ func (receive *GoTicker) CalculateWaitList(quantity int) (waitList []int64, err
 // calculateToNextDay calculates the remaining time until the next day based on the current time in a given timezone, and the date provided.
 func (receive *GoTicker) calculateToNextDay() (waitSecond int64, err error) {
 	// Get the current time in the timezone of the ticker
-	currentInTicker := time.Now().In(receive.BaseLocation)
+	currentInTicker := receive.Now().In(receive.BaseLocation)
 
 	// Create a time.Time object for the next day at 23:59:59 in the timezone of the ticker
 	var endOfDay time.Time
@@ -415,21 +523,24 @@ func (receive *GoTicker) calculateToNextDay() (waitSecond int64, err error) {
 // by calculating the time to wait until tomorrow,
 // setting a timer with the calculated wait time plus 2 seconds to ensure it enters the next day,
 // renewing the ticker for the next day, and setting the status to indicate recovery
-func (receive *GoTicker) waitForNextDay() (err error) {
+func (receive *GoTicker) waitForNextDay(ctx context.Context) (err error) {
 	// Set the status to indicate waiting for tomorrow
 	receive.Status.Store(StatusWaitForTomorrow)
 
 	// Calculate the time to wait until tomorrow
 	var waitForTomorrow int64
+	receive.Mu.Lock()
 	waitForTomorrow, err = receive.calculateToNextDay()
+	receive.Mu.Unlock()
 	if err != nil {
 		return
 	}
 
 	// Create a new timer with the calculated wait time plus 2 seconds
 	// The 2-second addition is to ensure that the timer really enters the next day !
-	timer := time.NewTimer(time.Duration(waitForTomorrow)*time.Second + 2*time.Second) // <- race -
-	<-timer.C
+	timer := receive.newTimer(time.Duration(waitForTomorrow)*time.Second + 2*time.Second) // <- race -
+	var replan bool
+	replan, err = receive.waitForTimer(ctx, timer)
 
 	/*
 		Stop the timer:
@@ -439,8 +550,16 @@ func (receive *GoTicker) waitForNextDay() (err error) {
 	*/
 	timer.Stop() // <- race -
 
+	// The updated options have already been loaded for the current date, so there is nothing to renew,
+	// and after a clock jump the caller plans again from the new time
+	if err != nil || replan {
+		return
+	}
+
 	// Renew the ticker for the next day
-	err = receive.ReNew()
+	receive.Mu.Lock()
+	err = receive.rollOver()
+	receive.Mu.Unlock()
 	if err != nil {
 		return
 	}
@@ -453,82 +572,498 @@ func (receive *GoTicker) waitForNextDay() (err error) {
 }
 
 // SendSignals sends signals at specific intervals and handles interruptions.
-func (receive *GoTicker) SendSignals(ctx context.Context, count int) (err error) {
+// The points are taken one at a time from a PointIterator, which is only recreated
+// when the options are updated or the ticker moves on to the next day.
+// Every send and every wait is abandoned as soon as the context is done,
+// and SendSignals then returns ErrUserInterrupted.
+func (receive *GoTicker) SendSignals(ctx context.Context) (err error) {
 	// Use atomic CAS to prevent multiple calls to SendSignals
 	if !atomic.CompareAndSwapUint32(&receive.Active32, 0, 1) {
 		return
 	}
+	// Allow SendSignals to be called again after it returns
+	defer atomic.StoreUint32(&receive.Active32, 0)
+
+	// Return err value
+	return receive.sendSignals(ctx)
+}
+
+// sendSignals is SendSignals for callers which have already taken the place of SendSignals.
+func (receive *GoTicker) sendSignals(ctx context.Context) (err error) {
+	// The last point sent, which a restarted stream must not send again
+	var fired int64
 
 	// the tickerz is active and loop until the context is done
 	for {
-		// Calculate the list of wait times
-		var waitLists []int64
-		waitLists, err = receive.CalculateWaitList(count)
+		// If the context is done, send a user interrupt signal and return
+		if ctx.Err() != nil {
+			err = receive.interrupt()
+			return
+		}
 
-		/*
-			If the wait list is inactive and repeat list is also inactive,
-			wait until the next day to produce the wait list
-		*/
-		if err == tickerBase.ErrInactiveBaseListAndRepeatList &&
-			receive.Status.Load() == StatusProducedWaitListBefore {
+		// Read the mode of the ticker, which may be changed by UpdateOpts
+		receive.Mu.Lock()
+		mode := receive.Mode
+		receive.Mu.Unlock()
 
-			// Send a signal to the ticker channel to wait until the next day to produce the wait list
-			receive.SignalChan <- tickerBase.TickerSignal{ // <- race -
-				SignalStatus: tickerBase.SignalWaitForTomorrow,
+		// In the computer mode, fire by elapsed time until the options are updated
+		if mode == tickerBase.CommputerMode {
+			err = receive.sendElapsedSignals(ctx)
+			if err != nil {
+				err = receive.interrupt()
+				return
 			}
+			continue
+		}
 
-			// Wait for the next day
-			err = receive.waitForNextDay()
+		// Start streaming the points from now, after the last point sent
+		iterator := receive.replanPoints(receive.Now().Unix(), fired)
+		receive.Status.Store(StatusProducedWaitListBefore)
+
+		// Read the precision of the new stream
+		receive.Mu.Lock()
+		precision := receive.Opts.Precision
+		receive.Mu.Unlock()
+
+		// Wait until each time point is reached
+		var replan bool
+		var onTime bool // whether the last point was sent on time
+		for {
+			waitPoint, ok := iterator.Next()
+			if !ok {
+				break
+			}
+			// Calculate the number of seconds to wait until the time point
+			now := receive.Now().Unix()
+			waitForSeconds := waitPoint - now
+			// The element the iterator yields on the repeated point just sent on time under OverlapFireBoth is on time as well
+			if waitForSeconds <= 0 && onTime && iterator.Companion() {
+				err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+					SignalStatus: tickerBase.SignalOnTime,
+					// Generate and set a serial number if a serial handler function exists
+					SerialNumber: receive.serialNumber(waitPoint),
+					TimeStamp:    waitPoint,
+					Drift:        receive.Now().Sub(time.Unix(waitPoint, 0)),
+					Source:       iterator.Source(),
+				})
+			} else if waitForSeconds > 0 {
+				target := time.Unix(waitPoint, 0)
+				wait := time.Duration(waitForSeconds) * time.Second
+				// In the precision mode, only sleep until shortly before the point
+				if precision > 0 {
+					wait = target.Sub(receive.Now()) - precision - coarseMargin
+				}
+				timer := receive.newTimer(wait) // <- race -
+				replan, err = receive.waitForTimer(ctx, timer)
+				// Stop the timer
+				timer.Stop() // <- race -
+				if err != nil {
+					err = receive.interrupt()
+					return
+				}
+				// If the options are updated or the clock jumped, restart the stream from now
+				if replan {
+					break
+				}
+				// In the precision mode, finish the wait with short sleeps and spinning (the mocked time does not need it)
+				if precision > 0 && !timer.mocked {
+					finishWait(target, precision)
+				}
+				// Send an on-time signal when the time point is reached.
+				err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+					SignalStatus: tickerBase.SignalOnTime,
+					// Generate and set a serial number if a serial handler function exists
+					SerialNumber: receive.serialNumber(waitPoint),
+					TimeStamp:    waitPoint,
+					Drift:        receive.Now().Sub(target),
+					Source:       iterator.Source(),
+				})
+				onTime = true
+			} else {
+				// Send an on-time signal with the delay time if the time point is already passed
+				onTime = false
+				err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+					SignalStatus: tickerBase.SignalDelay,
+					// Generate and set a serial number if a serial handler function exists
+					SerialNumber: receive.serialNumber(waitPoint),
+					TimeStamp:    waitPoint,
+					DelaySeconds: -1 * waitForSeconds,
+					Source:       iterator.Source(),
+				})
+			}
 			if err != nil {
+				err = receive.interrupt()
 				return
 			}
+			fired = waitPoint
+		}
+		if replan {
+			continue
+		}
+
+		/*
+			If no point is left in the base list and the repeat list,
+			wait until the next day to produce the points
+		*/
+		// Send a signal to the ticker channel to wait until the next day to produce the points
+		err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+			SignalStatus: tickerBase.SignalWaitForTomorrow,
+		})
+
+		// Wait for the next day
+		if err == nil {
+			err = receive.waitForNextDay(ctx)
+		}
+		if err == tickerBase.ErrUserInterrupted {
+			err = receive.interrupt()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// finishWait waits from shortly before the target until the target in the precision mode,
+// sleeping half of the remaining time while more than the precision is left and spinning for the rest.
+func finishWait(target time.Time, precision time.Duration) {
+	for {
+		remaining := time.Until(target)
+		switch {
+		case remaining <= 0:
+			return
+		case remaining > precision:
+			time.Sleep(remaining / 2)
+		default:
+			runtime.Gosched()
+		}
+	}
+}
+
+// sendSignal sends a signal to the ticker channel and to every subscription, each according to its own overflow policy.
+// All of them receive the same signal with the same serial number.
+func (receive *GoTicker) sendSignal(ctx context.Context, signal tickerBase.TickerSignal) (err error) {
+	// Collect the outlets under the lock, because the options and subscriptions may be updated
+	receive.Mu.Lock()
+	outlets := receive.outlets()
+	recorder := receive.SignalRecorder
+	receive.Mu.Unlock()
+
+	// Let Simulate record the signal, and keep it from the outlets unless the run emits it
+	if recorder != nil && !recorder(signal) {
+		return
+	}
+
+	// Send the signal to every outlet in turn
+	for i := 0; i < len(outlets); i++ {
+		err = outlets[i].send(ctx, receive.SendTimeout, signal)
+		if err != nil {
+			return
 		}
+	}
+
+	// Return err value
+	return
+}
+
+// interrupt sends a user interrupt signal to every outlet which can take it right now, and returns ErrUserInterrupted.
+// It never blocks, because the context is already done.
+func (receive *GoTicker) interrupt() (err error) {
+	// Collect the outlets under the lock, but a run of Simulate which is stopped does not interrupt the receivers
+	receive.Mu.Lock()
+	outlets := receive.outlets()
+	if receive.SignalRecorder != nil {
+		outlets = nil
+	}
+	receive.Mu.Unlock()
+
+	// Offer the signal to every outlet without waiting
+	for i := 0; i < len(outlets); i++ {
+		outlets[i].offer(tickerBase.TickerSignal{
+			SignalStatus: tickerBase.SignalUserInterrupt,
+		})
+	}
+
+	// Return err value
+	err = tickerBase.ErrUserInterrupted
+	return
+}
+
+// serialNumber generates a serial number for the time stamp if a serial handler function exists.
+func (receive *GoTicker) serialNumber(timeStamp int64) (serial uint64) {
+	// The serial handler mutates the serial base, so hold the lock while calling it
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Return the serial value
+	return receive.generateSerialNumber(timeStamp)
+}
 
-		// Loop through the wait list and wait until each time point is reached
-		for _, waitPoint := range waitLists {
-			select { // <- race -
-			// If the context is done, send a user interrupt signal and return
+// generateSerialNumber is serialNumber for callers which already hold the lock.
+func (receive *GoTicker) generateSerialNumber(timeStamp int64) (serial uint64) {
+	if receive.SerialHandler != nil {
+		serial = receive.SerialHandler(&receive.SerialBase, timeStamp)
+	}
+
+	// Return the serial value
+	return
+}
+
+// waitForTimer blocks until the timer fires,
+// sending a manual signal for every trigger request received in the meantime.
+// The timer keeps running, so the scheduled point is neither shifted nor consumed by a trigger.
+// If the options are updated while waiting, it sends a signal to report it and returns replan as true,
+// so that the caller can recompute its wait list.
+// The timer runs on the monotonic clock, so the wall clock is watched as well,
+// and if it jumps (the clock is stepped or the host is suspended), a signal reports the jump and replan is returned as true.
+// While the time is mocked, the timer fires once the mocked time reaches its deadline,
+// and mocking or unmocking the time while waiting returns replan as true.
+// If the context is done while waiting, it returns ErrUserInterrupted.
+func (receive *GoTicker) waitForTimer(ctx context.Context, timer waitTimer) (replan bool, err error) {
+	// Watch the wall clock against the monotonic clock while waiting,
+	// except in the computer mode which ignores the clock and while the time is mocked
+	receive.Mu.Lock()
+	mode := receive.Mode
+	mockChan := receive.MockChan
+	waitChan := receive.MockWaitChan
+	receive.Mu.Unlock()
+	var checkChan <-chan time.Time
+	if mode != tickerBase.CommputerMode && !timer.mocked {
+		check := time.NewTicker(clockCheckInterval)
+		defer check.Stop()
+		checkChan = check.C
+	}
+	start := time.Now()
+	startWall := receive.wallNow()
+
+	// Tell whoever moves the mocked time what it is waited for
+	if timer.mocked {
+		receive.MockWait.Store(timer.deadline.UnixNano())
+		defer receive.MockWait.Store(0)
+
+		// Hand the deadline over to Simulate, which moves the mocked time on to it
+		if waitChan != nil {
+			select {
+			case waitChan <- timer.deadline.UnixNano():
 			case <-ctx.Done():
-				receive.SignalChan <- tickerBase.TickerSignal{ // <- race -
-					SignalStatus: tickerBase.SignalUserInterrupt,
-				}
+				err = tickerBase.ErrUserInterrupted
 				return
-			default:
-				// Calculate the number of seconds to wait until the time point
-				now := time.Now().Unix()
-				waitForSeconds := waitPoint - now
-				// If the wait time is positive, wait until the time point is reached
-				if waitForSeconds > 0 {
-					timer := time.NewTimer(time.Duration(waitForSeconds) * time.Second) // <- race -
-					<-timer.C
-					// Send an on-time signal when the time point is reached.
-					receive.SignalChan <- tickerBase.TickerSignal{ // <- race -
-						SignalStatus: tickerBase.SignalOnTime,
-						// Generate and set a serial number if a serial handler function exists
-						SerialNumber: func() (serial uint64) {
-							if receive.SerialHandler != nil {
-								serial = receive.SerialHandler(&receive.SerialBase, waitPoint)
-							}
-							return
-						}(),
-					}
-					// Stop the timer
-					timer.Stop() // <- race -
-				} else {
-					// Send an on-time signal with the delay time if the time point is already passed
-					receive.SignalChan <- tickerBase.TickerSignal{ // <- race -
-						SignalStatus: tickerBase.SignalDelay,
-						// Generate and set a serial number if a serial handler function exists
-						SerialNumber: func() (serial uint64) {
-							if receive.SerialHandler != nil {
-								serial = receive.SerialHandler(&receive.SerialBase, waitPoint)
-							}
-							return
-						}(),
-						DelaySeconds: -1 * waitForSeconds,
-					}
-				}
 			}
 		}
 	}
+
+	for {
+		// The mocked time may have reached the deadline
+		if timer.mocked && !receive.Now().Before(timer.deadline) {
+			return
+		}
+
+		select {
+		case <-mockChan:
+			// Plan again if the time has been mocked or unmocked
+			if (receive.MockNano.Load() != 0) != timer.mocked {
+				replan = true
+				return
+			}
+		case <-timer.C:
+			// Make sure the wall clock still agrees before the point is sent
+			if checkChan != nil {
+				replan, err = receive.checkClock(ctx, start, startWall)
+			}
+			return
+		case <-checkChan:
+			replan, err = receive.checkClock(ctx, start, startWall)
+			if replan || err != nil {
+				return
+			}
+		case <-ctx.Done():
+			err = tickerBase.ErrUserInterrupted
+			return
+		case <-receive.TriggerChan:
+			// Send a manual signal stamped with the current time
+			now := receive.Now().Unix()
+			err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+				SignalStatus: tickerBase.SignalManual,
+				SerialNumber: receive.serialNumber(now),
+				TimeStamp:    now,
+			})
+			if err != nil {
+				return
+			}
+		case <-receive.ReloadChan:
+			// Report the updated options
+			err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+				SignalStatus: tickerBase.SignalOptsUpdated,
+			})
+			replan = true
+			return
+		}
+	}
+}
+
+// checkClock compares how far the wall clock and the monotonic clock have moved since the wait started,
+// and if they differ by clockJumpThreshold or more, sends a signal reporting the jump and returns jumped as true.
+func (receive *GoTicker) checkClock(ctx context.Context, start, startWall time.Time) (jumped bool, err error) {
+	// The wall clock is read without the monotonic reading, so the difference is the jump
+	jump := receive.wallNow().Sub(startWall) - time.Since(start)
+	if jump > -clockJumpThreshold && jump < clockJumpThreshold {
+		return
+	}
+
+	// Report the jump
+	jumped = true
+	err = receive.sendSignal(ctx, tickerBase.TickerSignal{
+		SignalStatus: tickerBase.SignalClockJumped,
+		TimeStamp:    receive.wallNow().Unix(),
+		JumpSize:     jump,
+	})
+
+	// Return the jumped and err values
+	return
+}
+
+// wallNow returns the current wall clock time of the ticker without the monotonic clock reading.
+func (receive *GoTicker) wallNow() time.Time {
+	// Use the wall clock of the ticker if it is set
+	if receive.WallClock != nil {
+		return receive.WallClock().Round(0)
+	}
+	return time.Now().Round(0)
+}
+
+// TriggerNow asks the running SendSignals loop, or the engine driving the ticker, to send a manual signal immediately.
+// A trigger requested while another one is still pending is merged into the pending one.
+func (receive *GoTicker) TriggerNow() (err error) {
+	// The trigger channel is only created by New
+	if receive.TriggerChan == nil {
+		err = tickerBase.ErrNotNewedTicker
+		return
+	}
+
+	// An attached ticker is triggered by its engine
+	receive.Mu.Lock()
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+	if request != nil {
+		request(engineTrigger)
+		return
+	}
+
+	// Queue the trigger request without blocking the caller
+	select {
+	case receive.TriggerChan <- struct{}{}:
+	default:
+	}
+
+	// Return err value
+	return
+}
+
+// UpdateOpts replaces the options of the ticker while it may be running.
+// The new options are validated and converted before they are swapped in under the lock,
+// so an invalid update leaves the ticker untouched.
+// The signal channel is not recreated, so a BufferSize other than its capacity returns ErrBufferSizeChanged,
+// and an engine only drives the human mode, so the computer mode for an attached ticker returns ErrUnSupportedMode.
+// A running SendSignals loop, or the engine driving the ticker, is woken up to recalculate its points
+// and reports the change with a signal.
+func (receive *GoTicker) UpdateOpts(opts tickerBase.Opts, offOpts tickerBase.OffOpts) (err error) {
+	// Check if the options are valid
+	err = opts.CheckOpts()
+	if err != nil {
+		return
+	}
+
+	// Convert the new options on a separate ticker
+	updated := &GoTicker{Opts: opts, OffOpts: offOpts, Mode: modeOf(opts)}
+	err = updated.ReloadLocation()
+	if err != nil {
+		return
+	}
+	updated.MockNano.Store(receive.MockNano.Load())
+	err = updated.UpdateNowDateOrMock("")
+	if err != nil {
+		return
+	}
+	err = updated.loadStamps()
+	if err != nil {
+		return
+	}
+
+	// Swap the configuration under the lock, where the signal channel keeps its buffer, so the size must not change
+	receive.Mu.Lock()
+	if receive.SignalChan != nil && cap(receive.SignalChan) != opts.BufferSize {
+		receive.Mu.Unlock()
+		err = tickerBase.ErrBufferSizeChanged
+		return
+	}
+	if receive.EngineRequest != nil && updated.Mode == tickerBase.CommputerMode {
+		receive.Mu.Unlock()
+		err = tickerBase.ErrUnSupportedMode
+		return
+	}
+	receive.Opts = updated.Opts
+	receive.OffOpts = updated.OffOpts
+	receive.Mode = updated.Mode
+	receive.NowDate = updated.NowDate
+	receive.BaseLocation = updated.BaseLocation
+	receive.BaseStamp = updated.BaseStamp
+	receive.BaseStampType = updated.BaseStampType
+	receive.BaseList = updated.BaseList
+	receive.BaseListType = updated.BaseListType
+	receive.BeginStamp = updated.BeginStamp
+	receive.BeginStampType = updated.BeginStampType
+	receive.EndStamp = updated.EndStamp
+	receive.EndStampType = updated.EndStampType
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+
+	// Ask the engine to plan the points again if the ticker is attached
+	if request != nil {
+		request(engineReload)
+		return
+	}
+
+	// Wake up the SendSignals loop without blocking the caller
+	select {
+	case receive.ReloadChan <- struct{}{}:
+	default:
+	}
+
+	// Return err value
+	return
+}
+
+// Snapshot returns an immutable copy of the configuration, status and upcoming points of the ticker.
+// It is safe to call while SendSignals is running.
+func (receive *GoTicker) Snapshot() (output tickerBase.Snapshot) {
+	// Hold the lock while the ticker is being copied
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Copy the configuration
+	output.NowDate = receive.NowDate
+	output.BaseLocation = receive.BaseLocation
+	output.BaseStamp = receive.BaseStamp
+	output.BaseStampType = receive.BaseStampType
+	output.BaseList = append([]int64(nil), receive.BaseList...)
+	output.BaseListType = receive.BaseListType
+	output.BeginStamp = receive.BeginStamp
+	output.BeginStampType = receive.BeginStampType
+	output.EndStamp = receive.EndStamp
+	output.EndStampType = receive.EndStampType
+	output.Mode = receive.Mode
+	output.Opts = receive.Opts
+	output.Opts.BaseList = append([]string(nil), receive.Opts.BaseList...)
+	output.OffOpts = receive.OffOpts
+
+	// Copy the status
+	output.Active = atomic.LoadUint32(&receive.Active32) == 1
+	output.Status = receive.Status.Load()
+	output.SerialBase = receive.SerialBase
+	output.PollStamp = receive.PollStamp
+
+	// Calculate the upcoming points without changing the status of the ticker
+	output.Upcoming, _ = receive.mergeSortedBaseListAndRepeat(SnapshotQuantity)
+
+	// Return the output value
+	return
 }
@@ -3,6 +3,9 @@ package goTicker
 import (
 	"context"
 	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -40,7 +43,7 @@ func Test_Race_waitForNextDay(t *testing.T) {
 	// Create 1000 goroutines to call waitForNextDay in order to check for data race
 	for i := 0; i < 1000; i++ {
 		go func() {
-			err = gt.waitForNextDay()
+			err = gt.waitForNextDay(context.Background())
 			if err != nil {
 				panic(err)
 			}
@@ -84,10 +87,80 @@ func Test_Race_SendSignals(t *testing.T) {
 	// Create 1000 goroutines to call SendSignals in order to check for data race
 	for i := 0; i < 1000; i++ {
 		go func() {
-			err := gt.SendSignals(context.Background(), 10)
+			err := gt.SendSignals(context.Background())
 			if err != nil && err != tickerBase.ErrAlreadyActive {
 				panic(err)
 			}
 		}()
 	}
 }
+
+// Test_Race_Snapshot is to check for data race between Snapshot and ReNew.
+func Test_Race_Snapshot(t *testing.T) {
+	// Create a new GoTicker with New, so ReNew really moves its time stamps
+	gt, err := New(tickerBase.Opts{
+		BaseTime:  "0:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Second,
+		BaseList:  []string{"12:0:0"},
+		BeginTime: "0:0:0",
+		EndTime:   "23:59:59",
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+
+	// Create 1000 goroutines to take snapshots while the ticker is being renewed in order to check for data race
+	var failed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = gt.Snapshot()
+			if gt.ReNew() != nil {
+				failed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every renewal has gone through
+	require.Equal(t, int64(0), failed.Load())
+}
+
+// Test_Race_Engine is to check for data race between a running engine and tickers being attached and detached.
+func Test_Race_Engine(t *testing.T) {
+	// Get the current time
+	now := time.Now()
+
+	// Run an engine
+	engine := NewEngine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = engine.Run(ctx)
+	}()
+
+	// Create 1000 goroutines to attach, refresh and detach tickers while the engine is running in order to check for data race
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gt := &GoTicker{
+				BaseStamp:  now.Unix(),
+				BeginStamp: now.Unix(),
+				EndStamp:   now.Add(2 * time.Second).Unix(),
+				Opts: tickerBase.Opts{
+					Duration:       time.Second,
+					OverflowPolicy: tickerBase.OverflowDropNewest,
+				},
+				SignalChan: make(chan tickerBase.TickerSignal, 1),
+			}
+			_ = engine.Attach(gt)
+			_ = engine.Refresh(gt)
+			_ = gt.Snapshot()
+			_ = engine.Detach(gt)
+		}()
+	}
+	wg.Wait()
+}
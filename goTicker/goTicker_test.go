@@ -4,6 +4,7 @@ import (
 	"context"
 	tickerBase "github.com/panhongrainbow/tickerz/base"
 	"github.com/stretchr/testify/require"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -45,13 +46,10 @@ func Test_Check_GoTicker_UpdateNowDateOrMockIfNeeded(t *testing.T) {
 	// Updates nowDate with mocked date if set
 	t.Run("Updates nowDate with mocked date if set", func(t *testing.T) {
 		gt := GoTicker{}
-		mockDateStr = "1970-1-1"
-		err := gt.updateNowDateOrMockAndReloadLocation(mockDateStr)
+		mockDate := "1970-1-1"
+		err := gt.updateNowDateOrMockAndReloadLocation(mockDate)
 		require.NoError(t, err)
-		require.Equal(t, mockDateStr, gt.NowDate)
-
-		// To eliminate the influence of the mock, reset the value of mockDateStr to its initial value
-		mockDateStr = ""
+		require.Equal(t, mockDate, gt.NowDate)
 	})
 }
 
@@ -103,7 +101,7 @@ func Test_Check_GoTicker_New(t *testing.T) {
 		}
 		offOpts := tickerBase.OffOpts{}
 		_, err := New(opts, offOpts)
-		require.Equal(t, tickerBase.ErrUnSupportedLocation, err)
+		require.ErrorIs(t, err, tickerBase.ErrUnSupportedLocation)
 	})
 	t.Run("valid opts", func(t *testing.T) {
 		opts := tickerBase.Opts{
@@ -116,16 +114,18 @@ func Test_Check_GoTicker_New(t *testing.T) {
 		}
 		offOpts := tickerBase.OffOpts{}
 
-		// mock date
-		mockDateStr = "2023-3-21"
-
 		// new ticker
 		gtk, err := New(opts, offOpts)
 		require.NoError(t, err)
+		// mock date, which does not move the date time formats
+		location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+		require.NoError(t, err)
+		err = gtk.MockTime(time.Date(2023, 3, 21, 0, 0, 0, 0, location))
+		require.NoError(t, err)
 		// check base stamp
 		require.Equal(t, int64(1677956645), gtk.BaseStamp)
 		// check location
-		require.Equal(t, "UTC", gtk.BaseLocation.String())
+		require.Equal(t, tickerBase.DefaultTimeZone, gtk.BaseLocation.String())
 		// check base list
 		require.Equal(t, int64(1677956646), gtk.BaseList[0])
 		require.Equal(t, int64(1677956647), gtk.BaseList[1])
@@ -153,17 +153,20 @@ func Test_Check_ReNew(t *testing.T) {
 		}
 		offOpts := tickerBase.OffOpts{}
 
-		// mock date
-		mockDateStr = "2023-1-31"
-
 		// new ticker
 		gtk, err := New(opts, offOpts)
 		require.NoError(t, err)
 
+		// mock date
+		location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+		require.NoError(t, err)
+		err = gtk.MockTime(time.Date(2023, 1, 31, 0, 0, 0, 0, location))
+		require.NoError(t, err)
+
 		// check base stamp
 		require.Equal(t, int64(1675105445), gtk.BaseStamp)
 		// check location
-		require.Equal(t, "UTC", gtk.BaseLocation.String())
+		require.Equal(t, tickerBase.DefaultTimeZone, gtk.BaseLocation.String())
 		// check base list
 		require.Equal(t, int64(1675105446), gtk.BaseList[0])
 		require.Equal(t, int64(1675105447), gtk.BaseList[1])
@@ -174,7 +177,8 @@ func Test_Check_ReNew(t *testing.T) {
 		require.Equal(t, int64(1675105447), gtk.EndStamp)
 
 		// mock date
-		mockDateStr = "2023-2-1"
+		err = gtk.MockTime(time.Date(2023, 2, 1, 0, 0, 0, 0, location))
+		require.NoError(t, err)
 
 		// renew ticker
 		err = gtk.ReNew()
@@ -183,7 +187,7 @@ func Test_Check_ReNew(t *testing.T) {
 		// check base stamp
 		require.Equal(t, int64(1675191845), gtk.BaseStamp)
 		// check location
-		require.Equal(t, "UTC", gtk.BaseLocation.String())
+		require.Equal(t, tickerBase.DefaultTimeZone, gtk.BaseLocation.String())
 		// check base list
 		require.Equal(t, int64(1675191846), gtk.BaseList[0])
 		require.Equal(t, int64(1675191847), gtk.BaseList[1])
@@ -546,9 +550,6 @@ func Test_Check_CalculateWaitTime(t *testing.T) {
 		}
 		offOpts := tickerBase.OffOpts{}
 
-		// mock date
-		mockDateStr = time.Now().Format(tickerBase.DefaultDateFormatStr)
-
 		// new ticker
 		gtk, err := New(opts, offOpts)
 		require.NoError(t, err)
@@ -587,9 +588,6 @@ func Test_Check_CalculateWaitTime(t *testing.T) {
 		}
 		offOpts := tickerBase.OffOpts{}
 
-		// mock date
-		mockDateStr = time.Now().Format(tickerBase.DefaultDateFormatStr)
-
 		// new ticker
 		gtk, err := New(opts, offOpts)
 		require.NoError(t, err)
@@ -650,7 +648,7 @@ func Test_Check_WaitAccordingList(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel()
 			go func() {
-				_ = test.ticker.SendSignals(ctx, 10)
+				_ = test.ticker.SendSignals(ctx)
 			}()
 		})
 	}
@@ -693,7 +691,7 @@ func Test_Check_SendSignals(t *testing.T) {
 		go func() {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
-			err = gt.SendSignals(ctx, 50)
+			err = gt.SendSignals(ctx)
 			require.NoError(t, err)
 		}()
 
@@ -752,9 +750,9 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Start a new goroutine to send signals from the ticker
 		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
 		go func() {
-			err = gt.SendSignals(ctx, 50)
-			require.NoError(t, err)
+			errChan <- gt.SendSignals(ctx)
 		}()
 
 		// Wait for a signal from the ticker and verify its serial number
@@ -765,6 +763,7 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Cancel the context to stop the goroutine sending signals from the ticker
 		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
 	})
 	t.Run("check timeStamp in SerialHandler", func(t *testing.T) {
 		// Get the current time
@@ -813,9 +812,9 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Start a new goroutine to send signals from the ticker
 		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
 		go func() {
-			err = gt.SendSignals(ctx, 50)
-			require.NoError(t, err)
+			errChan <- gt.SendSignals(ctx)
 		}()
 
 		// Wait for a signal from the ticker and verify its serial number
@@ -826,6 +825,7 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Cancel the context to stop the goroutine sending signals from the ticker
 		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
 	})
 	t.Run("check serialBase and timeStamp in SerialHandler", func(t *testing.T) {
 		// Get the current time
@@ -870,9 +870,9 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Start a new goroutine to send signals from the ticker
 		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
 		go func() {
-			err = gt.SendSignals(ctx, 50)
-			require.NoError(t, err)
+			errChan <- gt.SendSignals(ctx)
 		}()
 
 		// Wait for a signal from the ticker and verify its serial number
@@ -883,5 +883,563 @@ func Test_Check_SendSignals_SerialHandler(t *testing.T) {
 
 		// Cancel the context to stop the goroutine sending signals from the ticker
 		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+	})
+}
+
+/*
+Test_Check_TriggerNow checks that a manual trigger sends a SignalManual through the SerialHandler
+without shifting or consuming the next scheduled point in the wait list.
+*/
+func Test_Check_TriggerNow(t *testing.T) {
+	t.Run("trigger on a ticker which is not newed", func(t *testing.T) {
+		gt := &GoTicker{}
+		err := gt.TriggerNow()
+		require.Equal(t, tickerBase.ErrNotNewedTicker, err)
+	})
+	t.Run("trigger between scheduled points", func(t *testing.T) {
+		// Get the current time
+		now := time.Now()
+
+		// Create a new GoTicker and set its properties
+		gt := &GoTicker{
+			BaseStamp: now.Unix(),
+			BaseList: []int64{
+				now.Add(3 * time.Second).Unix(),
+			},
+			BeginStamp: now.Add(0 * time.Second).Unix(),
+			EndStamp:   now.Add(30 * time.Second).Unix(),
+			Opts: tickerBase.Opts{
+				Duration: time.Nanosecond,
+			},
+			// Set the serial handler function
+			SerialHandler: func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+				newSerial = uint64(timeStamp)
+				return
+			},
+		}
+
+		// Create the channels to receive signals and trigger requests
+		gt.SignalChan = make(chan tickerBase.TickerSignal)
+		gt.TriggerChan = make(chan struct{}, 1)
+		// Reload the location information for the ticker
+		err := gt.ReloadLocation()
+		require.NoError(t, err)
+		// Update the ticker's current date
+		err = gt.UpdateNowDateOrMock("")
+		require.NoError(t, err)
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			_ = gt.SendSignals(ctx)
+		}()
+
+		// Trigger the ticker manually and verify the manual signal
+		err = gt.TriggerNow()
+		require.NoError(t, err)
+		signalFromTicker := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalManual, signalFromTicker.SignalStatus)
+		require.LessOrEqual(t, uint64(now.Unix()), signalFromTicker.SerialNumber)
+		require.Greater(t, uint64(now.Add(3*time.Second).Unix()), signalFromTicker.SerialNumber)
+
+		// The scheduled point is still sent on time after the manual signal
+		signalFromTicker = <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signalFromTicker.SignalStatus)
+		require.Equal(t, uint64(now.Add(3*time.Second).Unix()), signalFromTicker.SerialNumber)
+
+		// Cancel the context to stop the goroutine sending signals from the ticker
+		cancel()
+	})
+}
+
+/*
+Test_Check_UpdateOpts checks that the options of a running ticker can be replaced,
+and that the SendSignals loop reports the change and follows the new options.
+*/
+func Test_Check_UpdateOpts(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// Get the current time
+	now := time.Now().In(location)
+
+	// Create a new ticker whose only point is far away
+	opts := tickerBase.Opts{
+		BaseTime:  now.Format(tickerBase.DefaultDateTimeFormatStr),
+		Location:  tickerBase.DefaultTimeZone,
+		BaseList:  []string{now.Add(20 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr)},
+		BeginTime: now.Add(-1 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr),
+		EndTime:   now.Add(30 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr),
+	}
+	gt, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	gt.SerialHandler = func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+		newSerial = uint64(timeStamp)
+		return
+	}
+
+	t.Run("invalid options leave the ticker untouched", func(t *testing.T) {
+		invalid := opts
+		invalid.Location = "heaven" // invalid
+		err := gt.UpdateOpts(invalid, tickerBase.OffOpts{})
+		require.ErrorIs(t, err, tickerBase.ErrUnSupportedLocation)
+		require.Equal(t, tickerBase.DefaultTimeZone, gt.Opts.Location)
+		require.Equal(t, []int64{now.Add(20 * time.Second).Unix()}, gt.BaseList)
+
+		// The signal channel keeps its buffer, so the size can not change
+		resized := opts
+		resized.BufferSize = 4
+		resized.OverflowPolicy = tickerBase.OverflowDropOldest
+		err = gt.UpdateOpts(resized, tickerBase.OffOpts{})
+		require.ErrorIs(t, err, tickerBase.ErrBufferSizeChanged)
+		require.Equal(t, 0, gt.Opts.BufferSize)
+	})
+	t.Run("running ticker follows the new options", func(t *testing.T) {
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			_ = gt.SendSignals(ctx)
+		}()
+
+		// Move the point closer
+		updated := opts
+		updated.BaseList = []string{now.Add(2 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr)}
+		err := gt.UpdateOpts(updated, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// The change is reported first, then the new point is sent on time
+		signalFromTicker := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOptsUpdated, signalFromTicker.SignalStatus)
+		signalFromTicker = <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signalFromTicker.SignalStatus)
+		require.Equal(t, uint64(now.Add(2*time.Second).Unix()), signalFromTicker.SerialNumber)
+
+		// Cancel the context to stop the goroutine sending signals from the ticker
+		cancel()
+	})
+}
+
+// Test_Check_UpdateOpts_Replan checks that a running ticker does not fire the point it just fired again
+// when it plans its points again after UpdateOpts.
+func Test_Check_UpdateOpts_Replan(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	// Create a ticker which fires every 10 seconds on the mocked time
+	opts := tickerBase.Opts{
+		BaseTime:   "12:0:0",
+		Location:   tickerBase.DefaultTimeZone,
+		Duration:   10 * time.Second,
+		BeginTime:  "11:0:0",
+		EndTime:    "13:0:0",
+		BufferSize: 16,
+	}
+	gt, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(at("2023-1-31", "11:59:55")))
+
+	// Start a new goroutine to send signals from the ticker, and fire the point at 12:00:00
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- gt.SendSignals(ctx)
+	}()
+	advanceMockTo(t, gt, at("2023-1-31", "12:0:0"))
+
+	// Update the options right after the point, and wait until the ticker plans again
+	updated := opts
+	updated.BaseList = []string{"12:0:5"}
+	require.NoError(t, gt.UpdateOpts(updated, tickerBase.OffOpts{}))
+	require.True(t, waitUntil(func() bool {
+		return gt.MockWait.Load() == at("2023-1-31", "12:0:5").UnixNano()
+	}))
+	advanceMockTo(t, gt, at("2023-1-31", "12:0:20"))
+	cancel()
+	require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+
+	// Every point is fired once, and the update is reported once
+	var stamps []string
+	var updates int
+	for len(gt.SignalChan) > 0 {
+		signal := <-gt.SignalChan
+		switch signal.SignalStatus {
+		case tickerBase.SignalOnTime, tickerBase.SignalDelay:
+			stamps = append(stamps, time.Unix(signal.TimeStamp, 0).In(location).Format("15:04:05"))
+		case tickerBase.SignalOptsUpdated:
+			updates++
+		}
+	}
+	require.Equal(t, []string{"12:00:00", "12:00:05", "12:00:10", "12:00:20"}, stamps)
+	require.Equal(t, 1, updates)
+}
+
+// Test_Check_Snapshot checks that a snapshot copies the state of the ticker and does not share memory with it.
+func Test_Check_Snapshot(t *testing.T) {
+	// Get the current time
+	now := time.Now()
+
+	// Create a new GoTicker and set its properties
+	gt := &GoTicker{
+		NowDate:   now.Format(tickerBase.DefaultDateFormatStr),
+		BaseStamp: now.Unix(),
+		BaseList: []int64{
+			now.Add(10 * time.Second).Unix(),
+			now.Add(20 * time.Second).Unix(),
+		},
+		BeginStamp: now.Unix(),
+		EndStamp:   now.Add(30 * time.Second).Unix(),
+		Opts: tickerBase.Opts{
+			BaseList: []string{"1:2:3"},
+			Duration: time.Nanosecond,
+		},
+		SerialBase: 10,
+	}
+	gt.Status.Store(StatusNewed)
+
+	// Take a snapshot
+	snapshot := gt.Snapshot()
+	require.Equal(t, gt.NowDate, snapshot.NowDate)
+	require.Equal(t, gt.BaseStamp, snapshot.BaseStamp)
+	require.Equal(t, gt.BaseList, snapshot.BaseList)
+	require.Equal(t, gt.EndStamp, snapshot.EndStamp)
+	require.Equal(t, StatusNewed, snapshot.Status)
+	require.Equal(t, false, snapshot.Active)
+	require.Equal(t, uint64(10), snapshot.SerialBase)
+	require.Equal(t, gt.BaseList, snapshot.Upcoming)
+
+	// Taking a snapshot does not change the status of the ticker
+	require.Equal(t, StatusNewed, gt.Status.Load())
+
+	// Changing the snapshot does not change the ticker
+	snapshot.BaseList[0] = 0
+	snapshot.Opts.BaseList[0] = ""
+	require.Equal(t, now.Add(10*time.Second).Unix(), gt.BaseList[0])
+	require.Equal(t, "1:2:3", gt.Opts.BaseList[0])
+}
+
+/*
+Test_Check_SendSignals_Context checks that SendSignals never blocks on a signal nobody receives:
+it returns promptly after the context is cancelled, and drops signals when the send timeout expires.
+*/
+func Test_Check_SendSignals_Context(t *testing.T) {
+	// newTicker creates a ticker with two points in the near future
+	newTicker := func(now time.Time) (gt *GoTicker) {
+		gt = &GoTicker{
+			BaseStamp: now.Unix(),
+			BaseList: []int64{
+				now.Add(1 * time.Second).Unix(),
+				now.Add(2 * time.Second).Unix(),
+			},
+			BeginStamp: now.Add(0 * time.Second).Unix(),
+			EndStamp:   now.Add(30 * time.Second).Unix(),
+			Opts: tickerBase.Opts{
+				Duration: time.Nanosecond,
+			},
+		}
+		// Create a channel which nobody receives from
+		gt.SignalChan = make(chan tickerBase.TickerSignal)
+		// Reload the location information for the ticker
+		err := gt.ReloadLocation()
+		require.NoError(t, err)
+		// Update the ticker's current date
+		err = gt.UpdateNowDateOrMock("")
+		require.NoError(t, err)
+		return
+	}
+
+	t.Run("returns after cancellation while nobody receives", func(t *testing.T) {
+		gt := newTicker(time.Now())
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- gt.SendSignals(ctx)
+		}()
+
+		// Cancel the context while the ticker is blocked on sending the first signal
+		time.Sleep(1500 * time.Millisecond)
+		cancel()
+
+		// SendSignals returns promptly
+		select {
+		case err := <-errChan:
+			require.Equal(t, tickerBase.ErrUserInterrupted, err)
+		case <-time.After(time.Second):
+			t.Fatal("SendSignals did not return after cancellation")
+		}
+		require.Equal(t, false, gt.Snapshot().Active)
+	})
+	t.Run("drops signals nobody receives within the send timeout", func(t *testing.T) {
+		gt := newTicker(time.Now())
+		gt.SendTimeout = 100 * time.Millisecond
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = gt.SendSignals(ctx)
+		}()
+
+		// Both points are dropped
+		require.Eventually(t, func() bool {
+			return gt.DroppedSignals.Load() == 2
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+// Test_Check_sendSignal_OverflowPolicy checks how each overflow policy handles signals which do not fit in the buffer.
+func Test_Check_sendSignal_OverflowPolicy(t *testing.T) {
+	t.Run("New creates a buffered signal channel", func(t *testing.T) {
+		opts := tickerBase.Opts{
+			BaseTime:       "2023-03-05 03:04:05",
+			BeginTime:      "2023-03-05 03:04:06",
+			EndTime:        "2023-03-05 03:04:07",
+			BufferSize:     4,
+			OverflowPolicy: tickerBase.OverflowDropOldest,
+		}
+		gtk, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 4, cap(gtk.SignalChan))
+	})
+
+	// Define the subtests, each sending serial numbers 1, 2 and 3 into a buffer of 2
+	tests := []struct {
+		name             string
+		overflowPolicy   uint
+		expectedSerials  []uint64
+		expectedCoalesce []uint64
+		expectedOverflow uint64
+	}{
+		{"drop newest", tickerBase.OverflowDropNewest, []uint64{1, 2}, []uint64{0, 0}, 1},
+		{"drop oldest", tickerBase.OverflowDropOldest, []uint64{2, 3}, []uint64{0, 0}, 1},
+		{"coalesce", tickerBase.OverflowCoalesce, []uint64{2, 3}, []uint64{0, 1}, 1},
+	}
+
+	// Run the subtests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gt := &GoTicker{
+				Opts: tickerBase.Opts{
+					BufferSize:     2,
+					OverflowPolicy: test.overflowPolicy,
+				},
+			}
+			gt.SignalChan = make(chan tickerBase.TickerSignal, 2)
+
+			// Nobody receives, so the third signal overflows without blocking
+			for serial := uint64(1); serial <= 3; serial++ {
+				err := gt.sendSignal(context.Background(), tickerBase.TickerSignal{
+					SignalStatus: tickerBase.SignalOnTime,
+					SerialNumber: serial,
+				})
+				require.NoError(t, err)
+			}
+
+			// Verify the signals left in the buffer and the overflow counter
+			for i := 0; i < 2; i++ {
+				signalFromTicker := <-gt.SignalChan
+				require.Equal(t, test.expectedSerials[i], signalFromTicker.SerialNumber)
+				require.Equal(t, test.expectedCoalesce[i], signalFromTicker.CoalescedSignals)
+			}
+			require.Equal(t, test.expectedOverflow, gt.OverflowCount.Load())
+		})
+	}
+
+	// An unbuffered channel which nobody receives drops the signal instead of spinning, whatever the policy
+	t.Run("unbuffered channel", func(t *testing.T) {
+		for _, policy := range []uint{tickerBase.OverflowDropNewest, tickerBase.OverflowDropOldest, tickerBase.OverflowCoalesce} {
+			gt := &GoTicker{
+				Opts: tickerBase.Opts{
+					OverflowPolicy: policy,
+				},
+			}
+			gt.SignalChan = make(chan tickerBase.TickerSignal)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			err := gt.sendSignal(ctx, tickerBase.TickerSignal{SignalStatus: tickerBase.SignalOnTime})
+			cancel()
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), gt.OverflowCount.Load())
+		}
 	})
 }
+
+// Test_Check_SendSignals_Precision checks that the precision mode sends the on-time signals close to their points and reports the drift.
+func Test_Check_SendSignals_Precision(t *testing.T) {
+	// Get the current time
+	now := time.Now()
+
+	// Create a new GoTicker in the precision mode
+	gt := &GoTicker{
+		BaseStamp: now.Unix(),
+		BaseList: []int64{
+			now.Add(1 * time.Second).Unix(),
+			now.Add(2 * time.Second).Unix(),
+		},
+		BeginStamp: now.Unix(),
+		EndStamp:   now.Add(30 * time.Second).Unix(),
+		Opts: tickerBase.Opts{
+			Duration:  time.Nanosecond,
+			Precision: time.Millisecond,
+		},
+	}
+	// Create a channel to receive signals from the ticker
+	gt.SignalChan = make(chan tickerBase.TickerSignal)
+	// Reload the location information for the ticker
+	err := gt.ReloadLocation()
+	require.NoError(t, err)
+	// Update the ticker's current date
+	err = gt.UpdateNowDateOrMock("")
+	require.NoError(t, err)
+
+	// Start a new goroutine to send signals from the ticker
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = gt.SendSignals(ctx)
+	}()
+
+	// The signals arrive right after their points, and the drift is what the receiver measures at most
+	for i := 0; i < 2; i++ {
+		signal := <-gt.SignalChan
+		arrived := time.Since(time.Unix(signal.TimeStamp, 0))
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.GreaterOrEqual(t, signal.Drift, time.Duration(0))
+		require.LessOrEqual(t, signal.Drift, arrived)
+		require.Less(t, arrived, 50*time.Millisecond)
+	}
+}
+
+// Test_Check_SendSignals_ClockJump checks that a jump of the wall clock during a wait is reported and the points are planned again.
+func Test_Check_SendSignals_ClockJump(t *testing.T) {
+	// Get the current time
+	now := time.Now()
+
+	// Create a new GoTicker and set its properties
+	gt := &GoTicker{
+		BaseStamp: now.Unix(),
+		BaseList: []int64{
+			now.Add(3 * time.Second).Unix(),
+		},
+		BeginStamp: now.Unix(),
+		EndStamp:   now.Add(30 * time.Second).Unix(),
+		Opts: tickerBase.Opts{
+			Duration: time.Nanosecond,
+		},
+	}
+	// Simulate the wall clock of the ticker with an offset which can be stepped
+	var offset atomic.Int64
+	gt.WallClock = func() time.Time {
+		return time.Now().Add(time.Duration(offset.Load()))
+	}
+	// Create a channel to receive signals from the ticker
+	gt.SignalChan = make(chan tickerBase.TickerSignal)
+	// Reload the location information for the ticker
+	err := gt.ReloadLocation()
+	require.NoError(t, err)
+	// Update the ticker's current date
+	err = gt.UpdateNowDateOrMock("")
+	require.NoError(t, err)
+
+	// Start a new goroutine to send signals from the ticker
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- gt.SendSignals(ctx)
+	}()
+
+	// Step the wall clock forward by an hour once the ticker is waiting
+	time.Sleep(500 * time.Millisecond)
+	offset.Store(int64(time.Hour))
+	signal := <-gt.SignalChan
+	require.Equal(t, tickerBase.SignalClockJumped, signal.SignalStatus)
+	require.InDelta(t, float64(time.Hour), float64(signal.JumpSize), float64(time.Second))
+
+	// The point is still sent after planning again
+	signal = <-gt.SignalChan
+	require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+	require.Equal(t, gt.BaseList[0], signal.TimeStamp)
+
+	// Stop the ticker
+	cancel()
+	require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+}
+
+// Test_Check_ZonedBaseList checks that BaseList elements with their own zones are merged into one ordered list,
+// and that every element falls on the date of the ticker after the day rollover.
+func Test_Check_ZonedBaseList(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// Create a ticker firing at 9 o'clock in Tokyo, London and Honolulu
+	gt, err := New(tickerBase.Opts{
+		BaseTime:  "0:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		BaseList:  []string{"9:0:0 [Asia/Tokyo]", "9:0:0 [Europe/London]", "9:0:0 [" + tickerBase.DefaultTestTimeZone + "]"},
+		BeginTime: "0:0:0",
+		EndTime:   "23:59:59",
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(time.Date(2023, 1, 31, 0, 0, 0, 0, location)))
+
+	// The elements are ordered by their time on the date of the ticker,
+	// where 9 o'clock in Honolulu is 3 o'clock of the next day in Shanghai, so the one of the day before comes first
+	require.Equal(t, []int64{
+		time.Date(2023, 1, 31, 3, 0, 0, 0, location).Unix(),
+		time.Date(2023, 1, 31, 8, 0, 0, 0, location).Unix(),
+		time.Date(2023, 1, 31, 17, 0, 0, 0, location).Unix(),
+	}, gt.BaseList)
+
+	// The day rollover moves every element on to the next date
+	require.NoError(t, gt.AdvanceMockTime(24*time.Hour))
+	require.Equal(t, []int64{
+		time.Date(2023, 2, 1, 3, 0, 0, 0, location).Unix(),
+		time.Date(2023, 2, 1, 8, 0, 0, 0, location).Unix(),
+		time.Date(2023, 2, 1, 17, 0, 0, 0, location).Unix(),
+	}, gt.BaseList)
+}
+
+// Test_Check_DateBaseList checks that the dates of the base list fire at the time of day of the options, mixed with date-times.
+func Test_Check_DateBaseList(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// Create a ticker for two settlement dates and a date-time, where the dates fire at 9 o'clock
+	gt, err := New(tickerBase.Opts{
+		BaseTime:   "0:0:0",
+		Location:   tickerBase.DefaultTimeZone,
+		BaseList:   []string{"2023-2-1", "2023-2-1 10:0:0", "2023-W06-1"},
+		BeginTime:  "0:0:0",
+		EndTime:    "23:59:59",
+		AllDayTime: "9:0:0",
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(time.Date(2023, 1, 31, 0, 0, 0, 0, location)))
+
+	// The dates are at 9 o'clock in the location, in order with the date-time
+	expected := []int64{
+		time.Date(2023, 2, 1, 9, 0, 0, 0, location).Unix(),
+		time.Date(2023, 2, 1, 10, 0, 0, 0, location).Unix(),
+		time.Date(2023, 2, 6, 9, 0, 0, 0, location).Unix(),
+	}
+	require.Equal(t, tickerBase.DatetimeFormat, gt.BaseListType)
+	require.Equal(t, expected, gt.BaseList)
+
+	// The day rollover keeps the dates where they are, and both points of the date fire on that date
+	require.NoError(t, gt.AdvanceMockTime(24*time.Hour))
+	require.Equal(t, expected, gt.BaseList)
+	iterator := gt.Points(time.Date(2023, 2, 1, 0, 0, 0, 0, location).Unix())
+	for i := 0; i < 2; i++ {
+		point, ok := iterator.Next()
+		require.True(t, ok)
+		require.Equal(t, expected[i], point)
+	}
+	_, ok := iterator.Next()
+	require.False(t, ok)
+}
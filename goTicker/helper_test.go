@@ -0,0 +1,66 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clockAt returns a function which gives the time of the clock on the date in the location, such as at("2023-1-31", "12:0:0").
+func clockAt(t testing.TB, location *time.Location) (at func(date, clock string) time.Time) {
+	// Return the at value
+	return func(date, clock string) (output time.Time) {
+		t.Helper()
+		output, err := time.ParseInLocation(tickerBase.DefaultDateTimeFormatStr, date+" "+clock, location)
+		require.NoError(t, err)
+		return
+	}
+}
+
+/*
+advanceMockTo moves the mocked time of the ticker forward to the target,
+stopping at every point a running SendSignals waits for on the way and waiting for SendSignals to take it,
+so every point fires on time and in order.
+*/
+func advanceMockTo(t testing.TB, gt *GoTicker, target time.Time) {
+	t.Helper()
+	for {
+		// Find the next point SendSignals waits for, which must not be after the target
+		var deadline int64
+		settled := waitUntil(func() bool {
+			deadline = gt.MockWait.Load()
+			return deadline != 0 || atomic.LoadUint32(&gt.Active32) == 0
+		})
+		require.True(t, settled, "SendSignals did not wait for a point")
+		if deadline == 0 || deadline > target.UnixNano() {
+			break
+		}
+
+		// Step to the point and wait for SendSignals to take it
+		require.NoError(t, gt.AdvanceMockTime(time.Unix(0, deadline).Sub(gt.Now())))
+		taken := waitUntil(func() bool {
+			return gt.MockWait.Load() != deadline
+		})
+		require.True(t, taken, "SendSignals did not take the point")
+	}
+
+	// Move the rest of the way
+	require.NoError(t, gt.AdvanceMockTime(target.Sub(gt.Now())))
+}
+
+// waitUntil waits for the condition for up to 5 seconds, and reports whether it is met.
+func waitUntil(condition func() bool) (met bool) {
+	expire := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(expire) {
+			return
+		}
+		time.Sleep(50 * time.Microsecond)
+	}
+	met = true
+
+	// Return the met value
+	return
+}
@@ -0,0 +1,234 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+/*
+PointIterator lazily yields the points of a ticker in order,
+merging BaseList and the repeated points one at a time instead of building a wait list,
+so it takes the same memory however short the Duration is.
+It keeps the time stamps the ticker had when the iterator was created, so it is not affected by ReNew or UpdateOpts.
+A BaseList element on a repeated point, or within OverlapTolerance of one, is merged into the repeated point,
+unless the overlap policy is OverlapFireBoth, in which case both are yielded with their own sources.
+No point is yielded on a date which is off in OffOpts, so every path which fires or lists points skips the days off alike.
+*/
+type PointIterator struct {
+	baseList  []int64 // the BaseList of the ticker, which is replaced rather than modified by ReNew
+	index     int     // the next element of baseList to look at
+	repeat    int64   // the next repeated point
+	repeated  bool    // whether there are repeated points
+	duration  int64   // the repeat duration in seconds
+	from      int64   // base list elements must come after this time
+	begin     int64
+	end       int64
+	last      int64   // the last point yielded, to drop duplicates
+	started   bool    // whether a point has been yielded
+	first     int64   // the first repeated point
+	policy    uint    // the overlap policy
+	tolerance int64   // the overlap tolerance in seconds
+	both      bool    // the last point is a repeated point, and the element on it is yielded next
+	companion bool    // the last point is the element yielded after the repeated point it is on
+	merged    []int64 // the repeated points ahead with an element merged into them within the tolerance, in order
+	source    uint    // the source of the last point
+	offOpts   tickerBase.OffOpts
+	location  *time.Location // the location the dates of the points are taken in
+}
+
+// Points returns an iterator over the points of the ticker from the given Unix time,
+// which are exactly the points CalculateWaitList would list, without a limit on their number.
+func (receive *GoTicker) Points(from int64) (iterator *PointIterator) {
+	// Hold the lock while the time stamps are being read
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Return the iterator value
+	return receive.points(from)
+}
+
+// points is Points for callers which already hold the lock.
+func (receive *GoTicker) points(from int64) (iterator *PointIterator) {
+	// Calculate the first repeated point, which is the nearest one at or before the given time
+	headRepeatList, duration, _ := receive.calculateRepeatParameterAt(from)
+
+	// [fix] To prevent the repeated elements from going before the beginStamp boundary
+	if duration >= 1 && headRepeatList < receive.BeginStamp {
+		headRepeatList = headRepeatList + (receive.BeginStamp-headRepeatList+duration-1)/duration*duration
+	}
+
+	// Create the iterator
+	iterator = &PointIterator{
+		baseList:  receive.BaseList,
+		duration:  duration,
+		from:      from,
+		begin:     receive.BeginStamp,
+		end:       receive.EndStamp,
+		policy:    receive.Opts.OverlapPolicy,
+		tolerance: int64(receive.Opts.OverlapTolerance / time.Second),
+		offOpts:   receive.OffOpts,
+		location:  receive.BaseLocation,
+	}
+	if iterator.location == nil {
+		iterator.location = time.UTC
+	}
+	if duration >= 1 {
+		iterator.repeat = headRepeatList
+		iterator.first = headRepeatList
+		iterator.repeated = true
+	}
+
+	// Return the iterator value
+	return
+}
+
+/*
+replanPoints restarts the points of the ticker from the given Unix time, after the options are updated,
+the clock jumped or moved, or the ticker moved on to the next date.
+Points starts with the repeated point at or before now, so the points up to the last one fired are left out,
+which keeps a restart from firing that point again. A fired of 0 means no point has fired yet,
+and a point fired after now, when the clock is moved back, does not hold the restarted points up.
+*/
+func (receive *GoTicker) replanPoints(now, fired int64) (iterator *PointIterator) {
+	iterator = receive.Points(now)
+	if fired != 0 && fired <= now {
+		iterator.skipTo(fired + 1)
+	}
+
+	// Return the iterator value
+	return
+}
+
+// Next returns the next point, or ok as false when no point is left before the end of the window.
+func (receive *PointIterator) Next() (point int64, ok bool) {
+	for {
+		point, ok = receive.next()
+		if !ok {
+			return
+		}
+
+		// Skip the rest of a date which is off
+		day := time.Unix(point, 0).In(receive.location)
+		if !receive.offOpts.IsOff(day.Weekday()) {
+			return
+		}
+		nextDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, receive.location).AddDate(0, 0, 1)
+		receive.skipTo(nextDay.Unix())
+	}
+}
+
+// next returns the next point of the merged sequences, whether its date is off or not.
+func (receive *PointIterator) next() (point int64, ok bool) {
+	// Find the next BaseList element within the window, in the future and after the last point,
+	// which is not merged into a repeated point
+	for receive.index < len(receive.baseList) {
+		element := receive.baseList[receive.index]
+		if element > receive.begin &&
+			element < receive.end &&
+			element > receive.from &&
+			(!receive.started || element > receive.last || (receive.both && element == receive.last)) &&
+			!receive.mergeNear(element) {
+			break
+		}
+		receive.index++
+	}
+
+	// Check which of the two sequences still has a point before the end
+	baseOk := receive.index < len(receive.baseList)
+	repeatOk := receive.repeated && receive.repeat < receive.end
+
+	// Take the smaller point, and advance both sequences when they meet
+	both := false
+	switch {
+	case baseOk && repeatOk && receive.baseList[receive.index] == receive.repeat:
+		point = receive.repeat
+		receive.repeat += receive.duration
+		receive.source = tickerBase.SourceMerged
+		// Yield the element as well after the repeated point
+		if receive.policy == tickerBase.OverlapFireBoth {
+			receive.source = tickerBase.SourceRepeat
+			both = true
+			break
+		}
+		receive.index++
+	case baseOk && (!repeatOk || receive.baseList[receive.index] < receive.repeat):
+		point = receive.baseList[receive.index]
+		receive.index++
+		receive.source = tickerBase.SourceBaseList
+	case repeatOk:
+		point = receive.repeat
+		receive.repeat += receive.duration
+		receive.source = tickerBase.SourceRepeat
+		// Drop the merged points which are passed
+		for len(receive.merged) > 0 && receive.merged[0] < point {
+			receive.merged = receive.merged[1:]
+		}
+		if len(receive.merged) > 0 && receive.merged[0] == point {
+			receive.source = tickerBase.SourceMerged
+		}
+	default:
+		return
+	}
+	receive.companion = receive.both && point == receive.last
+	receive.both = both
+	receive.last = point
+	receive.started = true
+	ok = true
+
+	// Return the point and ok values
+	return
+}
+
+// skipTo moves both sequences on to their first points at or after the Unix time.
+func (receive *PointIterator) skipTo(stamp int64) {
+	for receive.index < len(receive.baseList) && receive.baseList[receive.index] < stamp {
+		receive.index++
+	}
+	if receive.repeated && receive.repeat < stamp {
+		receive.repeat += (stamp - receive.repeat + receive.duration - 1) / receive.duration * receive.duration
+	}
+	receive.both = false
+}
+
+// Companion reports whether the last point returned by Next is a BaseList element on the repeated point returned just before it,
+// which only happens under OverlapFireBoth.
+func (receive *PointIterator) Companion() bool {
+	return receive.companion
+}
+
+// Source returns where the last point returned by Next comes from, which is one of the Source constants.
+func (receive *PointIterator) Source() uint {
+	return receive.source
+}
+
+/*
+mergeNear reports whether the element is within the tolerance of a repeated point, but not on it,
+and is merged into that point, which is not done under OverlapFireBoth.
+*/
+func (receive *PointIterator) mergeNear(element int64) (merged bool) {
+	if !receive.repeated || receive.tolerance <= 0 || receive.policy == tickerBase.OverlapFireBoth {
+		return
+	}
+
+	// Find the nearest repeated point
+	offset := (element - receive.first) % receive.duration
+	if offset < 0 {
+		offset += receive.duration
+	}
+	nearest, distance := element-offset, offset
+	if receive.duration-offset < offset {
+		nearest, distance = element+receive.duration-offset, receive.duration-offset
+	}
+	if distance == 0 || distance > receive.tolerance || nearest < receive.first || nearest >= receive.end {
+		return
+	}
+
+	// Mark the repeated point as merged, unless it has been yielded already
+	if nearest >= receive.repeat && (len(receive.merged) == 0 || receive.merged[len(receive.merged)-1] != nearest) {
+		receive.merged = append(receive.merged, nearest)
+	}
+	merged = true
+
+	// Return the merged value
+	return
+}
@@ -0,0 +1,125 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_PointIterator checks that the iterator merges BaseList and the repeated points in order, without duplicates.
+func Test_Check_PointIterator(t *testing.T) {
+	t.Run("merge base list and repeat", func(t *testing.T) {
+		// Create a new GoTicker repeating every 10 seconds, with base list elements on and between the repeated points
+		gt := &GoTicker{
+			BaseStamp: 1000,
+			BaseList: []int64{
+				995, // before the begin stamp
+				1005,
+				1010, // the same as a repeated point
+				1015,
+				1015, // a duplicate
+				1050, // after the end stamp
+			},
+			BeginStamp: 1001,
+			EndStamp:   1040,
+			Opts: tickerBase.Opts{
+				Duration: 10 * time.Second,
+			},
+		}
+
+		// Iterate from the begin stamp
+		iterator := gt.Points(1001)
+		var points []int64
+		for {
+			point, ok := iterator.Next()
+			if !ok {
+				break
+			}
+			points = append(points, point)
+		}
+		require.Equal(t, []int64{1005, 1010, 1015, 1020, 1030}, points)
+
+		// The wait list is the head of the same sequence
+		waitList, err := gt.mergeSortedBaseListAndRepeatAt(3, 1001)
+		require.NoError(t, err)
+		require.Equal(t, points[:3], waitList)
+	})
+	t.Run("a whole day of seconds", func(t *testing.T) {
+		// Create a new GoTicker repeating every second for a day
+		gt := &GoTicker{
+			BaseStamp:  0,
+			BeginStamp: 0,
+			EndStamp:   86400,
+			Opts: tickerBase.Opts{
+				Duration: time.Second,
+			},
+		}
+
+		// Every second of the day is yielded once, in order
+		iterator := gt.Points(0)
+		var count, previous int64 = 0, -1
+		for {
+			point, ok := iterator.Next()
+			if !ok {
+				break
+			}
+			require.Equal(t, previous+1, point)
+			previous = point
+			count++
+		}
+		require.Equal(t, int64(86400), count)
+	})
+	t.Run("overlap policies", func(t *testing.T) {
+		// collect takes every point with its source
+		collect := func(gt *GoTicker) (points []int64, sources []uint) {
+			iterator := gt.Points(1001)
+			for {
+				point, ok := iterator.Next()
+				if !ok {
+					break
+				}
+				points = append(points, point)
+				sources = append(sources, iterator.Source())
+			}
+			return
+		}
+
+		// Create a new GoTicker repeating every 10 seconds, with an element on a repeated point and one near another
+		gt := &GoTicker{
+			BaseStamp:  1000,
+			BaseList:   []int64{1010, 1022},
+			BeginStamp: 1001,
+			EndStamp:   1040,
+			Opts: tickerBase.Opts{
+				Duration: 10 * time.Second,
+			},
+		}
+
+		// The element on the repeated point is merged into it by default
+		points, sources := collect(gt)
+		require.Equal(t, []int64{1010, 1020, 1022, 1030}, points)
+		require.Equal(t, []uint{tickerBase.SourceMerged, tickerBase.SourceRepeat, tickerBase.SourceBaseList, tickerBase.SourceRepeat}, sources)
+
+		// Both points fire with their own sources under OverlapFireBoth
+		gt.Opts.OverlapPolicy = tickerBase.OverlapFireBoth
+		gt.Opts.OverlapTolerance = 5 * time.Second
+		points, sources = collect(gt)
+		require.Equal(t, []int64{1010, 1010, 1020, 1022, 1030}, points)
+		require.Equal(t, []uint{tickerBase.SourceRepeat, tickerBase.SourceBaseList, tickerBase.SourceRepeat, tickerBase.SourceBaseList, tickerBase.SourceRepeat}, sources)
+
+		// Only the element on the repeated point just before it is its companion
+		var companions []bool
+		iterator := gt.Points(1001)
+		for _, ok := iterator.Next(); ok; _, ok = iterator.Next() {
+			companions = append(companions, iterator.Companion())
+		}
+		require.Equal(t, []bool{false, true, false, false, false}, companions)
+
+		// The element within the tolerance of a repeated point is merged into it
+		gt.Opts.OverlapPolicy = tickerBase.OverlapMerge
+		points, sources = collect(gt)
+		require.Equal(t, []int64{1010, 1020, 1030}, points)
+		require.Equal(t, []uint{tickerBase.SourceMerged, tickerBase.SourceMerged, tickerBase.SourceRepeat}, sources)
+	})
+}
@@ -0,0 +1,164 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"math"
+	"time"
+)
+
+// Now returns the ticker's notion of the current time, which is the mocked time while it is mocked.
+func (receive *GoTicker) Now() (now time.Time) {
+	// Use the mocked time if it is set
+	now = time.Now()
+	if mockNano := receive.MockNano.Load(); mockNano != 0 {
+		now = time.Unix(0, mockNano)
+	}
+
+	// Return the now value
+	return
+}
+
+/*
+MockTime pins the ticker's notion of now, and so of today, to the given time and sets the status to StatusMockTime.
+The time stamps are moved to the mocked date, and a running SendSignals plans again from the mocked time,
+after which the points only come due when AdvanceMockTime moves the mocked time past them.
+Every ticker has its own mocked time, so tickers in parallel tests do not affect each other.
+An engine driving the ticker plans its points again from the mocked time.
+*/
+func (receive *GoTicker) MockTime(now time.Time) (err error) {
+	// Hold the lock while the time stamps are being moved
+	receive.Mu.Lock()
+
+	// Pin the time and move on to its date
+	receive.MockNano.Store(now.UnixNano())
+	err = receive.moveMockTime()
+	if err == nil {
+		receive.Status.Store(StatusMockTime)
+	}
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+
+	// Ask the engine to plan the points again, outside the lock which the engine takes itself
+	if err == nil && request != nil {
+		request(engineReplan)
+	}
+
+	// Return err value
+	return
+}
+
+// AdvanceMockTime moves the mocked time of the ticker forward by d, and moves the time stamps on to its date.
+// It returns ErrNotMockedTicker if the time is not mocked.
+// An engine driving the ticker fires the points the mocked time has reached.
+func (receive *GoTicker) AdvanceMockTime(d time.Duration) (err error) {
+	// Hold the lock while the time stamps are being moved
+	receive.Mu.Lock()
+
+	// Only a mocked time can be advanced
+	mockNano := receive.MockNano.Load()
+	if mockNano == 0 {
+		receive.Mu.Unlock()
+		err = tickerBase.ErrNotMockedTicker
+		return
+	}
+
+	// Move the time and its date
+	receive.MockNano.Store(mockNano + int64(d))
+	err = receive.moveMockTime()
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+
+	// Wake the engine up, outside the lock which the engine takes itself
+	if err == nil && request != nil {
+		request(engineWake)
+	}
+
+	// Return err value
+	return
+}
+
+// UnmockTime returns the ticker to the system clock and moves the time stamps back to the current date.
+func (receive *GoTicker) UnmockTime() (err error) {
+	// Hold the lock while the time stamps are being moved
+	receive.Mu.Lock()
+
+	// Only a mocked time can be unmocked
+	if receive.MockNano.Load() == 0 {
+		receive.Mu.Unlock()
+		err = tickerBase.ErrNotMockedTicker
+		return
+	}
+
+	// Clear the time and move on to the current date
+	receive.MockNano.Store(0)
+	err = receive.moveMockTime()
+	receive.Status.CompareAndSwap(StatusMockTime, StatusNewed)
+	request := receive.EngineRequest
+	receive.Mu.Unlock()
+
+	// Ask the engine to plan the points again on the system clock
+	if err == nil && request != nil {
+		request(engineReplan)
+	}
+
+	// Return err value
+	return
+}
+
+// moveMockTime moves the time stamps on to the date of the ticker's notion of now, and wakes up a waiting SendSignals.
+// The caller must hold the lock.
+func (receive *GoTicker) moveMockTime() (err error) {
+	// Make sure the location is loaded, so the date is taken in it
+	if receive.BaseLocation == nil {
+		err = receive.reloadLocation()
+		if err != nil {
+			return
+		}
+	}
+
+	// Move on to the date of now
+	err = receive.rollOver()
+	if err != nil {
+		return
+	}
+
+	// Wake up SendSignals without blocking
+	if receive.MockChan == nil {
+		receive.MockChan = make(chan struct{}, 1)
+	}
+	select {
+	case receive.MockChan <- struct{}{}:
+	default:
+	}
+
+	// Return err value
+	return
+}
+
+/*
+waitTimer is a timer on the ticker's notion of now.
+While the time is mocked it never fires on its own,
+and waitForTimer checks its deadline whenever the mocked time moves instead.
+*/
+type waitTimer struct {
+	*time.Timer
+	deadline time.Time
+	mocked   bool
+}
+
+// newTimer creates a timer which fires after d on the ticker's notion of now.
+func (receive *GoTicker) newTimer(d time.Duration) (timer waitTimer) {
+	timer.deadline = receive.Now().Add(d)
+	timer.mocked = receive.MockNano.Load() != 0
+
+	// A timer for the mocked time is never started
+	if timer.mocked {
+		timer.Timer = time.NewTimer(math.MaxInt64)
+		timer.Stop()
+		return
+	}
+	timer.Timer = time.NewTimer(d)
+
+	// Return the timer value
+	return
+}
@@ -0,0 +1,104 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_MockTime checks that every ticker has its own mocked time, and that SendSignals follows the mocked time.
+func Test_Check_MockTime(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// at returns the time of the clock on the date in the ticker's location
+	at := clockAt(t, location)
+
+	// Options of a ticker which fires once a day
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+
+	t.Run("pin, advance and unmock", func(t *testing.T) {
+		t.Parallel()
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.Equal(t, tickerBase.ErrNotMockedTicker, gt.AdvanceMockTime(time.Hour))
+
+		// Pin the time
+		require.NoError(t, gt.MockTime(at("2023-1-31", "12:0:0")))
+		require.Equal(t, StatusMockTime, gt.Status.Load())
+		require.Equal(t, at("2023-1-31", "12:0:0").Unix(), gt.Now().Unix())
+		require.Equal(t, "2023-1-31", gt.NowDate)
+		require.Equal(t, at("2023-1-31", "12:30:0").Unix(), gt.BaseList[0])
+
+		// Advance the time across midnight
+		require.NoError(t, gt.AdvanceMockTime(24*time.Hour))
+		require.Equal(t, "2023-2-1", gt.NowDate)
+		require.Equal(t, at("2023-2-1", "12:30:0").Unix(), gt.BaseList[0])
+
+		// Back to the system clock
+		require.NoError(t, gt.UnmockTime())
+		require.Equal(t, StatusNewed, gt.Status.Load())
+		require.Equal(t, time.Now().In(location).Format(tickerBase.DefaultDateFormatStr), gt.NowDate)
+		require.Equal(t, tickerBase.ErrNotMockedTicker, gt.UnmockTime())
+	})
+	t.Run("send signals on the mocked time", func(t *testing.T) {
+		t.Parallel()
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.NoError(t, gt.MockTime(at("2023-1-31", "12:0:0")))
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- gt.SendSignals(ctx)
+		}()
+		require.Eventually(t, func() bool {
+			return gt.Status.Load() == StatusProducedWaitListBefore
+		}, time.Second, time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		// Nothing is due until the mocked time moves
+		select {
+		case signal := <-gt.SignalChan:
+			t.Fatalf("unexpected signal %v", signal)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		// The point comes due once the mocked time reaches it
+		require.NoError(t, gt.AdvanceMockTime(30*time.Minute))
+		signal := <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.Equal(t, at("2023-1-31", "12:30:0").Unix(), signal.TimeStamp)
+		require.Equal(t, time.Duration(0), signal.Drift)
+		require.Equal(t, tickerBase.SignalWaitForTomorrow, (<-gt.SignalChan).SignalStatus)
+		require.Eventually(t, func() bool {
+			return gt.Status.Load() == StatusWaitForTomorrow
+		}, time.Second, time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		// The ticker rolls over to the next mocked date
+		require.NoError(t, gt.AdvanceMockTime(23*time.Hour))
+		require.Eventually(t, func() bool {
+			return gt.Status.Load() == StatusProducedWaitListBefore
+		}, time.Second, time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, gt.AdvanceMockTime(time.Hour))
+		signal = <-gt.SignalChan
+		require.Equal(t, tickerBase.SignalOnTime, signal.SignalStatus)
+		require.Equal(t, at("2023-2-1", "12:30:0").Unix(), signal.TimeStamp)
+
+		// Stop the ticker
+		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+	})
+}
@@ -0,0 +1,209 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+// dueQuantity is the number of points calculated at a time while collecting the due points.
+const dueQuantity = 100
+
+/*
+Due returns a signal for every point which became due since the last poll, up to and including now.
+It is meant for event loops which can not dedicate a goroutine to SendSignals.
+The first poll covers the point at or before now, as SendSignals starts with it, so a point exactly due is on time.
+Like an engine, polling only schedules clock times, so the computer mode returns ErrUnSupportedMode.
+When now is on a later date than the ticker, the remaining points of every date in between are collected
+and the time stamps are renewed date by date, as SendSignals does at the day rollover.
+*/
+func (receive *GoTicker) Due(now time.Time) (output []tickerBase.TickerSignal, err error) {
+	// Hold the lock while the ticker is being polled
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Polling only schedules clock times
+	if receive.Mode == tickerBase.CommputerMode {
+		err = tickerBase.ErrUnSupportedMode
+		return
+	}
+
+	// Make sure the location is loaded
+	if receive.BaseLocation == nil {
+		err = receive.reloadLocation()
+		if err != nil {
+			return
+		}
+	}
+
+	// Find the date of now in the ticker's location
+	nowStamp := now.Unix()
+	nowDate := now.In(receive.BaseLocation).Format(tickerBase.DefaultDateFormatStr)
+
+	// The first poll moves the ticker to the date of now, and returns the point at or before now
+	if receive.PollStamp == 0 {
+		receive.NowDate = nowDate
+		err = receive.renewStamps()
+		if err != nil {
+			return
+		}
+		if point, ok := receive.points(nowStamp).Next(); ok && point <= nowStamp {
+			output = append(output, receive.dueSignal(point, nowStamp))
+		}
+		receive.PollStamp = nowStamp
+		return
+	}
+
+	for {
+		// Collect the due points of the current date
+		dueList := receive.dueList(receive.PollStamp, nowStamp)
+		for i := 0; i < len(dueList); i++ {
+			output = append(output, receive.dueSignal(dueList[i], nowStamp))
+		}
+
+		// Stop when the ticker has caught up with the date of now
+		var nextDate string
+		nextDate, err = receive.nextDate(nowDate)
+		if err != nil || nextDate == "" {
+			break
+		}
+
+		// Move on to the next date
+		receive.NowDate = nextDate
+		err = receive.renewStamps()
+		if err != nil {
+			return
+		}
+	}
+
+	// Record where the next poll starts
+	receive.PollStamp = nowStamp
+
+	// Return the output and err values
+	return
+}
+
+/*
+NextDue returns the time of the next point Due is going to return,
+or the start of the next date when no point is left for the current date of the ticker,
+so an event loop knows how long it can sleep before polling Due again.
+Before the first poll it is the point the first poll would return, which is at or before now if there is one.
+As Due, it returns ErrUnSupportedMode in the computer mode.
+*/
+func (receive *GoTicker) NextDue() (next time.Time, err error) {
+	// Hold the lock while the ticker is being read
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+
+	// Polling only schedules clock times
+	if receive.Mode == tickerBase.CommputerMode {
+		err = tickerBase.ErrUnSupportedMode
+		return
+	}
+
+	// Make sure the location is loaded
+	if receive.BaseLocation == nil {
+		err = receive.reloadLocation()
+		if err != nil {
+			return
+		}
+	}
+
+	// Without any poll yet, start from the current time, where the point at or before it is due as well
+	from := receive.PollStamp
+	first := from == 0
+	if first {
+		from = receive.Now().Unix()
+	}
+
+	// Look for the first point after the last poll
+	iterator := receive.points(from)
+	for {
+		point, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		if first || point > from {
+			next = time.Unix(point, 0).In(receive.BaseLocation)
+			return
+		}
+	}
+
+	// No point is left, so wake up at the start of the next date
+	var date time.Time
+	date, err = time.ParseInLocation(tickerBase.DefaultDateFormatStr, receive.NowDate, receive.BaseLocation)
+	if err != nil {
+		err = tickerBase.ErrTimeParsion
+		return
+	}
+	next = date.AddDate(0, 0, 1)
+
+	// Return the next and err values
+	return
+}
+
+// dueSignal returns the signal of a point polled at now, which is late if the point is already passed.
+// The caller must hold the lock.
+func (receive *GoTicker) dueSignal(point, now int64) (signal tickerBase.TickerSignal) {
+	signal = tickerBase.TickerSignal{
+		SignalStatus: tickerBase.SignalOnTime,
+		SerialNumber: receive.generateSerialNumber(point),
+		TimeStamp:    point,
+	}
+
+	// Report the delay if the point is already passed
+	if point < now {
+		signal.SignalStatus = tickerBase.SignalDelay
+		signal.DelaySeconds = now - point
+	}
+
+	// Return the signal value
+	return
+}
+
+// dueList lists the points after from, up to and including to, for the current date of the ticker.
+// The caller must hold the lock.
+func (receive *GoTicker) dueList(from, to int64) (output []int64) {
+	for {
+		// The errors only report inactive lists, which simply produce no points
+		waitList, _ := receive.mergeSortedBaseListAndRepeatAt(dueQuantity, from)
+		for i := 0; i < len(waitList); i++ {
+			if waitList[i] > to {
+				return
+			}
+			if waitList[i] > from {
+				output = append(output, waitList[i])
+			}
+		}
+
+		// A short list means there are no more points
+		if len(waitList) < dueQuantity {
+			return
+		}
+		from = waitList[len(waitList)-1]
+	}
+}
+
+// nextDate returns the date after NowDate if it is not later than the target date, or an empty string otherwise.
+// The caller must hold the lock.
+func (receive *GoTicker) nextDate(target string) (next string, err error) {
+	// Parse both dates in the ticker's location
+	var current, last time.Time
+	current, err = time.ParseInLocation(tickerBase.DefaultDateFormatStr, receive.NowDate, receive.BaseLocation)
+	if err != nil {
+		err = tickerBase.ErrTimeParsion
+		return
+	}
+	last, err = time.ParseInLocation(tickerBase.DefaultDateFormatStr, target, receive.BaseLocation)
+	if err != nil {
+		err = tickerBase.ErrTimeParsion
+		return
+	}
+
+	// Only move forward up to the target date
+	if current.Before(last) {
+		next = current.AddDate(0, 0, 1).Format(tickerBase.DefaultDateFormatStr)
+	}
+
+	// Return the next and err values
+	return
+}
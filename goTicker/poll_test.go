@@ -0,0 +1,132 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Due polls a ticker across a day rollover and checks the returned signals and the next due times.
+func Test_Check_Due(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// at returns the time of the clock on the date in the ticker's location
+	at := clockAt(t, location)
+
+	// Create a new ticker on a mocked date
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+	gt, err := New(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(at("2023-1-31", "0:0:0")))
+	gt.SerialHandler = func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+		newSerial = uint64(timeStamp)
+		return
+	}
+
+	// Before the first poll, the point exactly due is next
+	require.NoError(t, gt.AdvanceMockTime(at("2023-1-31", "11:0:0").Sub(at("2023-1-31", "0:0:0"))))
+	next, err := gt.NextDue()
+	require.NoError(t, err)
+	require.Equal(t, at("2023-1-31", "11:0:0").Unix(), next.Unix())
+
+	// The first poll returns the point at the begin time, which is exactly due
+	signals, err := gt.Due(at("2023-1-31", "11:0:0"))
+	require.NoError(t, err)
+	require.Equal(t, []tickerBase.TickerSignal{
+		{SignalStatus: tickerBase.SignalOnTime, SerialNumber: uint64(at("2023-1-31", "11:0:0").Unix()), TimeStamp: at("2023-1-31", "11:0:0").Unix()},
+	}, signals)
+	next, err = gt.NextDue()
+	require.NoError(t, err)
+	require.Equal(t, at("2023-1-31", "12:0:0").Unix(), next.Unix())
+
+	// Two points passed since the first poll
+	signals, err = gt.Due(at("2023-1-31", "12:45:0"))
+	require.NoError(t, err)
+	require.Equal(t, []tickerBase.TickerSignal{
+		{SignalStatus: tickerBase.SignalDelay, SerialNumber: uint64(at("2023-1-31", "12:0:0").Unix()), TimeStamp: at("2023-1-31", "12:0:0").Unix(), DelaySeconds: 2700},
+		{SignalStatus: tickerBase.SignalDelay, SerialNumber: uint64(at("2023-1-31", "12:30:0").Unix()), TimeStamp: at("2023-1-31", "12:30:0").Unix(), DelaySeconds: 900},
+	}, signals)
+	next, err = gt.NextDue()
+	require.NoError(t, err)
+	require.Equal(t, at("2023-1-31", "13:0:0").Unix(), next.Unix())
+
+	// Polling exactly at the point is on time
+	signals, err = gt.Due(at("2023-1-31", "13:0:0"))
+	require.NoError(t, err)
+	require.Equal(t, []tickerBase.TickerSignal{
+		{SignalStatus: tickerBase.SignalOnTime, SerialNumber: uint64(at("2023-1-31", "13:0:0").Unix()), TimeStamp: at("2023-1-31", "13:0:0").Unix()},
+	}, signals)
+
+	// No point is left today, so the next poll is at the start of tomorrow
+	next, err = gt.NextDue()
+	require.NoError(t, err)
+	require.Equal(t, at("2023-2-1", "0:0:0").Unix(), next.Unix())
+
+	// Polling two days later collects the whole day in between, including the repeated point at the begin time
+	signals, err = gt.Due(at("2023-2-2", "12:0:0"))
+	require.NoError(t, err)
+	require.Equal(t, 6, len(signals))
+	require.Equal(t, uint64(at("2023-2-1", "11:0:0").Unix()), signals[0].SerialNumber)
+	require.Equal(t, uint64(at("2023-2-1", "12:0:0").Unix()), signals[1].SerialNumber)
+	require.Equal(t, uint64(at("2023-2-1", "12:30:0").Unix()), signals[2].SerialNumber)
+	require.Equal(t, uint64(at("2023-2-1", "13:0:0").Unix()), signals[3].SerialNumber)
+	require.Equal(t, uint64(at("2023-2-2", "11:0:0").Unix()), signals[4].SerialNumber)
+	require.Equal(t, tickerBase.TickerSignal{
+		SignalStatus: tickerBase.SignalOnTime,
+		SerialNumber: uint64(at("2023-2-2", "12:0:0").Unix()),
+		TimeStamp:    at("2023-2-2", "12:0:0").Unix(),
+	}, signals[5])
+	require.Equal(t, "2023-2-2", gt.NowDate)
+}
+
+// Test_Check_Due_First checks the first poll after the begin time and the computer mode.
+func Test_Check_Due_First(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+
+	t.Run("late first poll", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		require.NoError(t, gt.MockTime(at("2023-1-31", "12:10:0")))
+
+		// The point at or before now is late, and the one before it is left out
+		signals, err := gt.Due(at("2023-1-31", "12:10:0"))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(signals))
+		require.Equal(t, tickerBase.SignalDelay, signals[0].SignalStatus)
+		require.Equal(t, at("2023-1-31", "12:0:0").Unix(), signals[0].TimeStamp)
+		require.Equal(t, int64(600), signals[0].DelaySeconds)
+	})
+	t.Run("computer mode", func(t *testing.T) {
+		computer := opts
+		computer.Mode = tickerBase.CommputerMode
+		gt, err := New(computer, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// Polling does not measure elapsed time
+		_, err = gt.Due(at("2023-1-31", "12:0:0"))
+		require.Equal(t, tickerBase.ErrUnSupportedMode, err)
+		_, err = gt.NextDue()
+		require.Equal(t, tickerBase.ErrUnSupportedMode, err)
+	})
+}
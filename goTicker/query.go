@@ -0,0 +1,232 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+// searchDays is the number of dates the queries look through, which covers every weekly pattern of OffOpts.
+const searchDays = 366
+
+// IsActive reports whether t is inside the window of the ticker, on a date which is not off.
+func (receive *GoTicker) IsActive(t time.Time) (active bool, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// Check the window of the date of t
+	var begin, end int64
+	var ok bool
+	begin, end, ok, err = day.windowForDate(day.dateOf(t))
+	active = ok && begin <= t.Unix() && t.Unix() < end
+
+	// Return the active and err values
+	return
+}
+
+// NextOccurrence returns the first point the ticker fires after t.
+func (receive *GoTicker) NextOccurrence(t time.Time) (next time.Time, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// Look through the dates from the date of t onwards
+	date := day.dateOf(t)
+	for i := 0; i < searchDays; i++ {
+		// Skip the dates without a window
+		var begin, end int64
+		var ok bool
+		begin, end, ok, err = day.windowForDate(date)
+		if err != nil {
+			return
+		}
+		if ok {
+			// Start looking just before the window, so a repeated point at the beginning is included
+			from := begin - 1
+			if from < t.Unix() {
+				from = t.Unix()
+			}
+			// The errors only report inactive lists, which simply produce no points
+			waitList, _ := day.mergeSortedBaseListAndRepeatAt(2, from)
+			for j := 0; j < len(waitList); j++ {
+				if waitList[j] > from && waitList[j] >= begin && waitList[j] < end {
+					next = time.Unix(waitList[j], 0).In(day.BaseLocation)
+					return
+				}
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+
+	// No point is found
+	err = tickerBase.ErrInactiveBaseListAndRepeatList
+
+	// Return the next and err values
+	return
+}
+
+// PreviousOccurrence returns the last point the ticker fired before t.
+func (receive *GoTicker) PreviousOccurrence(t time.Time) (previous time.Time, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// A point is before t if it is before the first whole second after t
+	limit := t.Unix()
+	if t.Nanosecond() > 0 {
+		limit++
+	}
+
+	// Look through the dates from the date of t backwards
+	date := day.dateOf(t)
+	for i := 0; i < searchDays; i++ {
+		// Skip the dates without a window
+		var begin, end int64
+		var ok bool
+		begin, end, ok, err = day.windowForDate(date)
+		if err != nil {
+			return
+		}
+		if ok {
+			if end > limit {
+				end = limit
+			}
+			if point, found := day.lastPoint(begin, end); found {
+				previous = time.Unix(point, 0).In(day.BaseLocation)
+				return
+			}
+		}
+		date = date.AddDate(0, 0, -1)
+	}
+
+	// No point is found
+	err = tickerBase.ErrInactiveBaseListAndRepeatList
+
+	// Return the previous and err values
+	return
+}
+
+/*
+NextWindow returns the beginning and the end of the first window which opens after t.
+The windows of consecutive dates which touch each other are returned as one window,
+and a window which is already open at t is not returned, so use IsActive to check that.
+*/
+func (receive *GoTicker) NextWindow(t time.Time) (begin, end time.Time, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// Look through the dates from the date of t onwards
+	var openStamp, closeStamp int64
+	date := day.dateOf(t)
+	for i := 0; i < searchDays; i++ {
+		var dayBegin, dayEnd int64
+		var ok bool
+		dayBegin, dayEnd, ok, err = day.windowForDate(date)
+		if err != nil {
+			return
+		}
+		switch {
+		case ok && openStamp != 0 && dayBegin == closeStamp:
+			// The window goes on from the previous date
+			closeStamp = dayEnd
+		case openStamp != 0:
+			// The window has closed
+			begin = time.Unix(openStamp, 0).In(day.BaseLocation)
+			end = time.Unix(closeStamp, 0).In(day.BaseLocation)
+			return
+		case ok && dayBegin > t.Unix() && dayBegin != closeStamp:
+			// The window opens after t, and does not go on from a window which is open at t
+			openStamp, closeStamp = dayBegin, dayEnd
+		case ok:
+			// Remember the end of a window which is open at t
+			closeStamp = dayEnd
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+
+	// The window is still open at the end of the search
+	if openStamp != 0 {
+		begin = time.Unix(openStamp, 0).In(day.BaseLocation)
+		end = time.Unix(closeStamp, 0).In(day.BaseLocation)
+		return
+	}
+
+	// No window is found
+	err = tickerBase.ErrNoWindow
+
+	// Return the begin, end and err values
+	return
+}
+
+// dateOf returns the start of the date of t in the location of a ticker returned by dayTicker.
+func (receive *GoTicker) dateOf(t time.Time) (output time.Time) {
+	t = t.In(receive.BaseLocation)
+	output = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, receive.BaseLocation)
+
+	// Return the output value
+	return
+}
+
+/*
+windowForDate changes a ticker returned by dayTicker to the date,
+and returns the part of its window on the date, or ok as false when the date is off or has no window.
+*/
+func (receive *GoTicker) windowForDate(dayStart time.Time) (begin, end int64, ok bool, err error) {
+	// Nothing is open on a day off
+	if receive.OffOpts.IsOff(dayStart.Weekday()) {
+		return
+	}
+
+	// Convert the options into the time stamps of the date
+	receive.NowDate = dayStart.Format(tickerBase.DefaultDateFormatStr)
+	err = receive.loadStamps()
+	if err != nil {
+		return
+	}
+
+	// Clip the window to the date
+	begin, end = receive.BeginStamp, receive.EndStamp
+	if begin < dayStart.Unix() {
+		begin = dayStart.Unix()
+	}
+	if nextDayStart := dayStart.AddDate(0, 0, 1).Unix(); end > nextDayStart {
+		end = nextDayStart
+	}
+	ok = begin < end
+
+	// Return the begin, end, ok and err values
+	return
+}
+
+// lastPoint returns the last point from begin (inclusive) to end (exclusive) of a ticker returned by dayTicker.
+// The points are taken from a PointIterator, so the elements are merged into the repeated points as they are when firing.
+func (receive *GoTicker) lastPoint(begin, end int64) (point int64, found bool) {
+	// Start the points at the beginning of the window
+	iterator := receive.Points(begin - 1)
+	iterator.skipTo(begin)
+
+	// Keep the last point before the end
+	for {
+		next, ok := iterator.Next()
+		if !ok || next >= end {
+			break
+		}
+		point, found = next, true
+	}
+
+	// Return the point and found values
+	return
+}
@@ -0,0 +1,159 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Queries checks IsActive, NextOccurrence, PreviousOccurrence and NextWindow across day boundaries and days off.
+func Test_Check_Queries(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// at returns the time of the clock on the date in the ticker's location
+	at := clockAt(t, location)
+
+	// Create a new ticker on a mocked date, which is off on Sundays
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+	gt, err := New(opts, tickerBase.OffOpts{SundayOff: true})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(at("2023-1-31", "0:0:0")))
+
+	t.Run("is active", func(t *testing.T) {
+		for _, tc := range []struct {
+			date, clock string
+			active      bool
+		}{
+			{"2024-3-9", "10:59:59", false},
+			{"2024-3-9", "11:0:0", true},
+			{"2024-3-9", "13:59:59", true},
+			{"2024-3-9", "14:0:0", false},
+			{"2024-3-10", "12:0:0", false}, // Sunday
+		} {
+			active, err := gt.IsActive(at(tc.date, tc.clock))
+			require.NoError(t, err)
+			require.Equal(t, tc.active, active, tc.date+" "+tc.clock)
+		}
+	})
+	t.Run("next occurrence", func(t *testing.T) {
+		next, err := gt.NextOccurrence(at("2024-3-9", "12:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "12:30:0"), next)
+
+		// Skip the evening and the Sunday
+		next, err = gt.NextOccurrence(at("2024-3-9", "13:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-11", "11:0:0"), next)
+	})
+	t.Run("previous occurrence", func(t *testing.T) {
+		previous, err := gt.PreviousOccurrence(at("2024-3-9", "13:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "12:30:0"), previous)
+
+		previous, err = gt.PreviousOccurrence(at("2024-3-9", "12:30:0").Add(time.Millisecond))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "12:30:0"), previous)
+
+		// Skip the morning and the Sunday
+		previous, err = gt.PreviousOccurrence(at("2024-3-11", "10:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "13:0:0"), previous)
+	})
+	t.Run("next window", func(t *testing.T) {
+		begin, end, err := gt.NextWindow(at("2024-3-9", "10:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "11:0:0"), begin)
+		require.Equal(t, at("2024-3-9", "14:0:0"), end)
+
+		// An open window is not the next one
+		begin, end, err = gt.NextWindow(at("2024-3-9", "12:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-11", "11:0:0"), begin)
+		require.Equal(t, at("2024-3-11", "14:0:0"), end)
+	})
+	t.Run("window across dates", func(t *testing.T) {
+		// A window from Friday noon to Monday noon, which is off on Sunday
+		gt := &GoTicker{
+			Opts: tickerBase.Opts{
+				BaseTime:  "2024-3-8 12:0:0",
+				Location:  tickerBase.DefaultTimeZone,
+				Duration:  time.Hour,
+				BeginTime: "2024-3-8 12:0:0",
+				EndTime:   "2024-3-11 12:0:0",
+			},
+			OffOpts: tickerBase.OffOpts{SundayOff: true},
+		}
+
+		active, err := gt.IsActive(at("2024-3-9", "23:0:0"))
+		require.NoError(t, err)
+		require.True(t, active)
+		active, err = gt.IsActive(at("2024-3-10", "1:0:0"))
+		require.NoError(t, err)
+		require.False(t, active)
+
+		// The window reopens on Monday after the Sunday off
+		begin, end, err := gt.NextWindow(at("2024-3-9", "12:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-11", "0:0:0"), begin)
+		require.Equal(t, at("2024-3-11", "12:0:0"), end)
+
+		// Friday and Saturday make one window
+		begin, end, err = gt.NextWindow(at("2024-3-8", "0:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-8", "12:0:0"), begin)
+		require.Equal(t, at("2024-3-10", "0:0:0"), end)
+
+		// The points go on across midnight
+		next, err := gt.NextOccurrence(at("2024-3-8", "23:30:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-9", "0:0:0"), next)
+		previous, err := gt.PreviousOccurrence(at("2024-3-11", "0:30:0"))
+		require.NoError(t, err)
+		require.Equal(t, at("2024-3-11", "0:0:0"), previous)
+
+		// Nothing opens after the window
+		_, _, err = gt.NextWindow(at("2024-3-11", "12:0:0"))
+		require.Equal(t, tickerBase.ErrNoWindow, err)
+	})
+}
+
+// Test_Check_Queries_Merged checks that the queries agree with firing on an element merged into a repeated point.
+func Test_Check_Queries_Merged(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	// Create a new ticker repeating every hour, with an element within the tolerance of the point at 10:00
+	gt, err := New(tickerBase.Opts{
+		BaseTime:         "9:0:0",
+		Location:         tickerBase.DefaultTimeZone,
+		Duration:         time.Hour,
+		BaseList:         []string{"10:0:30"},
+		BeginTime:        "8:0:0",
+		EndTime:          "12:0:0",
+		OverlapTolerance: time.Minute,
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+
+	// The element never fires, so every query sees the repeated point only
+	occurrences, err := gt.Occurrences(at("2024-3-9", "9:30:0"), at("2024-3-9", "10:30:0"))
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{at("2024-3-9", "10:0:0")}, occurrences)
+	next, err := gt.NextOccurrence(at("2024-3-9", "9:30:0"))
+	require.NoError(t, err)
+	require.Equal(t, at("2024-3-9", "10:0:0"), next)
+	previous, err := gt.PreviousOccurrence(at("2024-3-9", "10:5:0"))
+	require.NoError(t, err)
+	require.Equal(t, at("2024-3-9", "10:0:0"), previous)
+}
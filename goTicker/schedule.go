@@ -0,0 +1,107 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"time"
+)
+
+/*
+ScheduleForDate returns every point the ticker fires on the date (in DefaultDateFormatStr) in its location.
+It only evaluates the options, so it answers for past and future dates alike without touching the state of the ticker,
+and it returns nothing for a date which is off in OffOpts.
+*/
+func (receive *GoTicker) ScheduleForDate(date string) (output []time.Time, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// Return the output and err values
+	return day.scheduleForDate(date)
+}
+
+/*
+Occurrences returns every point the ticker fires from from (inclusive) to to (exclusive), in its location.
+Like ScheduleForDate it only evaluates the options, date by date, so the range can be anywhere in the past or the future.
+*/
+func (receive *GoTicker) Occurrences(from, to time.Time) (output []time.Time, err error) {
+	// Copy the options, so the evaluation does not depend on the state of the ticker
+	var day *GoTicker
+	day, err = receive.dayTicker()
+	if err != nil {
+		return
+	}
+
+	// Evaluate every date in the range
+	first := from.In(day.BaseLocation)
+	date := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, day.BaseLocation)
+	for date.Before(to) {
+		var points []time.Time
+		points, err = day.scheduleForDate(date.Format(tickerBase.DefaultDateFormatStr))
+		if err != nil {
+			return
+		}
+		for i := 0; i < len(points); i++ {
+			if !points[i].Before(from) && points[i].Before(to) {
+				output = append(output, points[i])
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+
+	// Return the output and err values
+	return
+}
+
+// dayTicker returns a ticker which only carries the options and the location of the ticker,
+// for evaluating the schedule of any date.
+func (receive *GoTicker) dayTicker() (output *GoTicker, err error) {
+	// Hold the lock while the options are being copied
+	receive.Mu.Lock()
+	output = &GoTicker{
+		BaseLocation: receive.BaseLocation,
+		Opts:         receive.Opts,
+		OffOpts:      receive.OffOpts,
+	}
+	output.Opts.BaseList = append([]string(nil), receive.Opts.BaseList...)
+	receive.Mu.Unlock()
+
+	// Make sure the location is loaded
+	if output.BaseLocation == nil {
+		err = output.reloadLocation()
+	}
+
+	// Return the output and err values
+	return
+}
+
+// scheduleForDate is ScheduleForDate for a ticker returned by dayTicker, which is changed to the date.
+func (receive *GoTicker) scheduleForDate(date string) (output []time.Time, err error) {
+	// Parse the date in the ticker's location
+	var dayStart time.Time
+	dayStart, err = time.ParseInLocation(tickerBase.DefaultDateFormatStr, date, receive.BaseLocation)
+	if err != nil {
+		err = tickerBase.ErrTimeParsion
+		return
+	}
+
+	// Only look at the part of the window on the date, where nothing is open on a day off
+	var begin, end int64
+	var ok bool
+	begin, end, ok, err = receive.windowForDate(dayStart)
+	if err != nil || !ok {
+		return
+	}
+
+	// Collect the points
+	// (the repeated points start at the begin time, so start looking just before it)
+	points := receive.dueList(begin-1, end-1)
+	for i := 0; i < len(points); i++ {
+		output = append(output, time.Unix(points[i], 0).In(receive.BaseLocation))
+	}
+
+	// Return the output and err values
+	return
+}
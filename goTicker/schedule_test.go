@@ -0,0 +1,154 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test_Check_ScheduleForDate checks the points of arbitrary dates and ranges, and that days off are honoured.
+func Test_Check_ScheduleForDate(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// at returns the time of the clock on the date in the ticker's location
+	at := clockAt(t, location)
+
+	// Create a new ticker on a mocked date, which is off on Sundays
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+	gt, err := New(opts, tickerBase.OffOpts{SundayOff: true})
+	require.NoError(t, err)
+	require.NoError(t, gt.MockTime(at("2023-1-31", "0:0:0")))
+	before := gt.Snapshot()
+
+	t.Run("schedule for a date", func(t *testing.T) {
+		points, err := gt.ScheduleForDate("2024-3-9")
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			at("2024-3-9", "11:0:0"),
+			at("2024-3-9", "12:0:0"),
+			at("2024-3-9", "12:30:0"),
+			at("2024-3-9", "13:0:0"),
+		}, points)
+
+		// 2024-3-10 is a Sunday
+		points, err = gt.ScheduleForDate("2024-3-10")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(points))
+
+		_, err = gt.ScheduleForDate("2024/3/10")
+		require.Equal(t, tickerBase.ErrTimeParsion, err)
+	})
+	t.Run("occurrences in a range", func(t *testing.T) {
+		points, err := gt.Occurrences(at("2024-3-9", "12:0:0"), at("2024-3-11", "12:0:0"))
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			at("2024-3-9", "12:0:0"),
+			at("2024-3-9", "12:30:0"),
+			at("2024-3-9", "13:0:0"),
+			at("2024-3-11", "11:0:0"),
+		}, points)
+	})
+	t.Run("state is untouched", func(t *testing.T) {
+		after := gt.Snapshot()
+		require.Equal(t, before.NowDate, after.NowDate)
+		require.Equal(t, before.BaseList, after.BaseList)
+		require.Equal(t, before.BeginStamp, after.BeginStamp)
+		require.Equal(t, before.EndStamp, after.EndStamp)
+	})
+}
+
+// Test_Check_DayOff_Firing checks that the ticker fires, polls and lists its points across a day off as the schedule says.
+func Test_Check_DayOff_Firing(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := clockAt(t, location)
+
+	// Options of a ticker which fires at 9 and 21 o'clock, and is off on Sundays, where 2026-10-18 is a Sunday
+	opts := tickerBase.Opts{
+		BaseTime:   "9:0:0",
+		Location:   tickerBase.DefaultTimeZone,
+		Duration:   12 * time.Hour,
+		BeginTime:  "0:0:0",
+		EndTime:    "23:59:59",
+		BufferSize: 16,
+	}
+	offOpts := tickerBase.OffOpts{SundayOff: true}
+	from, to := at("2026-10-17", "8:0:0"), at("2026-10-19", "10:0:0")
+	expected := []time.Time{
+		at("2026-10-17", "9:0:0"),
+		at("2026-10-17", "21:0:0"),
+		at("2026-10-19", "9:0:0"),
+	}
+
+	t.Run("the schedule skips the day off", func(t *testing.T) {
+		gt, err := New(opts, offOpts)
+		require.NoError(t, err)
+		points, err := gt.ScheduleForDate("2026-10-18")
+		require.NoError(t, err)
+		require.Empty(t, points)
+		points, err = gt.Occurrences(from, to)
+		require.NoError(t, err)
+		require.Equal(t, expected, points)
+
+		// The snapshot on the day off lists nothing for the day
+		require.NoError(t, gt.MockTime(at("2026-10-18", "8:0:0")))
+		require.Empty(t, gt.Snapshot().Upcoming)
+	})
+	t.Run("send signals across the day off", func(t *testing.T) {
+		gt, err := New(opts, offOpts)
+		require.NoError(t, err)
+		require.NoError(t, gt.MockTime(from))
+
+		// Start a new goroutine to send signals from the ticker, and move the mocked time to the end
+		ctx, cancel := context.WithCancel(context.Background())
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- gt.SendSignals(ctx)
+		}()
+		require.True(t, waitUntil(func() bool {
+			return atomic.LoadUint32(&gt.Active32) == 1
+		}))
+		advanceMockTo(t, gt, to)
+		cancel()
+		require.Equal(t, tickerBase.ErrUserInterrupted, <-errChan)
+
+		// Only the points of the schedule have fired
+		var fired []time.Time
+		for len(gt.SignalChan) > 0 {
+			signal := <-gt.SignalChan
+			if signal.SignalStatus == tickerBase.SignalOnTime {
+				fired = append(fired, time.Unix(signal.TimeStamp, 0).In(location))
+			}
+		}
+		require.Equal(t, expected, fired)
+	})
+	t.Run("poll across the day off", func(t *testing.T) {
+		gt, err := New(opts, offOpts)
+		require.NoError(t, err)
+
+		// The first poll finds no point at or before the start
+		signals, err := gt.Due(from)
+		require.NoError(t, err)
+		require.Empty(t, signals)
+		signals, err = gt.Due(to)
+		require.NoError(t, err)
+		var polled []time.Time
+		for i := 0; i < len(signals); i++ {
+			polled = append(polled, time.Unix(signals[i].TimeStamp, 0).In(location))
+		}
+		require.Equal(t, expected, polled)
+	})
+}
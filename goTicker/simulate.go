@@ -0,0 +1,163 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"sync/atomic"
+	"time"
+)
+
+/*
+Simulate runs the ticker from from to to (exclusive) on a virtual clock, instead of waiting for the real points,
+so a schedule of days or months can be checked in seconds.
+It runs the loop of SendSignals itself on the mocked time, which hands over every point it waits for
+so the mocked time is moved on to it at once, and so it produces the same signals in the same order, including SignalWaitForTomorrow and the rollover to every next date,
+and lists them in the report. With opts.Speed the virtual clock runs that many times faster than the real clock,
+and with opts.Emit the signals are also sent to the channel and the subscriptions of the ticker.
+The ticker can not run SendSignals or be attached to an engine during the run,
+and its clock, status and serial base are put back afterwards.
+*/
+func (receive *GoTicker) Simulate(ctx context.Context, from, to time.Time, opts tickerBase.SimulationOpts) (report tickerBase.SimulationReport, err error) {
+	// Take the place of SendSignals, which must not run on the real clock at the same time
+	if !atomic.CompareAndSwapUint32(&receive.Active32, 0, 1) {
+		err = tickerBase.ErrAlreadyActive
+		return
+	}
+	defer atomic.StoreUint32(&receive.Active32, 0)
+
+	// Put the clock, the status and the serial base of the ticker back when the run is over
+	receive.Mu.Lock()
+	previousNano := receive.MockNano.Load()
+	previousSerial := receive.SerialBase
+	receive.Mu.Unlock()
+	previousStatus := receive.Status.Load()
+	defer func() {
+		receive.Mu.Lock()
+		receive.SignalRecorder = nil
+		receive.MockWaitChan = nil
+		receive.SerialBase = previousSerial
+		receive.MockNano.Store(previousNano)
+		restoreErr := receive.rollOver()
+		receive.Mu.Unlock()
+		receive.Status.Store(previousStatus)
+		if err == nil && restoreErr != tickerBase.ErrNoBaseLocation {
+			err = restoreErr
+		}
+	}()
+
+	// Record every signal of the run, and start the virtual clock at from
+	report.From, report.To = from, to
+	run := &simulation{ticker: receive, opts: opts, report: &report, now: from}
+	receive.Mu.Lock()
+	receive.SignalRecorder = run.record
+	receive.MockWaitChan = make(chan int64)
+	waitChan := receive.MockWaitChan
+	receive.MockNano.Store(from.UnixNano())
+	err = receive.moveMockTime()
+	receive.Mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	// Return the report and err values
+	err = run.drive(ctx, waitChan)
+	return
+}
+
+// simulation is the state of one run of Simulate.
+type simulation struct {
+	ticker *GoTicker
+	opts   tickerBase.SimulationOpts
+	report *tickerBase.SimulationReport
+	now    time.Time // the virtual clock
+}
+
+// drive runs the loop of SendSignals and moves the virtual clock on to every point it waits for, until the end of the run.
+func (receive *simulation) drive(ctx context.Context, waitChan chan int64) (err error) {
+	gt := receive.ticker
+
+	// Start the loop of SendSignals on the virtual clock, which is stopped at the end of the run
+	loopCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	var loopErr error
+	go func() {
+		loopErr = gt.sendSignals(loopCtx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for {
+		// Wait until the loop hands over the point it waits for
+		var deadline int64
+		select {
+		case deadline = <-waitChan:
+		case <-done:
+			// The loop only stops on its own when the context is done or the ticker fails
+			err = loopErr
+			return
+		}
+
+		// The loop goes on by itself past a point which is already reached
+		if deadline <= receive.now.UnixNano() {
+			continue
+		}
+
+		// The run is over before the point
+		at := time.Unix(0, deadline)
+		if !at.Before(receive.report.To) {
+			return
+		}
+
+		// A wait for the next date ends in a rollover
+		if gt.Status.Load() == StatusWaitForTomorrow {
+			receive.report.Rollovers++
+		}
+
+		// Move the virtual clock on to the point, which the loop then takes
+		err = receive.advance(ctx, at)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// advance moves the virtual clock on to the given time, taking the real time the speed asks for.
+func (receive *simulation) advance(ctx context.Context, to time.Time) (err error) {
+	// Take the real time of the step at the speed of the run
+	if receive.opts.Speed > 0 {
+		timer := time.NewTimer(time.Duration(float64(to.Sub(receive.now)) / receive.opts.Speed))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			err = tickerBase.ErrUserInterrupted
+			return
+		}
+	} else if ctx.Err() != nil {
+		err = tickerBase.ErrUserInterrupted
+		return
+	}
+
+	// Move the virtual clock, which wakes the loop up
+	err = receive.ticker.AdvanceMockTime(to.Sub(receive.now))
+	receive.now = to
+
+	// Return err value
+	return
+}
+
+// record adds a signal of the loop to the report, and tells whether the run sends it to the outlets of the ticker.
+func (receive *simulation) record(signal tickerBase.TickerSignal) (emit bool) {
+	// Record the signal
+	receive.report.Signals = append(receive.report.Signals, signal)
+	if signal.SignalStatus == tickerBase.SignalOnTime || signal.SignalStatus == tickerBase.SignalDelay {
+		receive.report.Firings++
+	}
+
+	// Return the emit value
+	emit = receive.opts.Emit
+	return
+}
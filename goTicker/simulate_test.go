@@ -0,0 +1,191 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test_Check_Simulate checks that Simulate replays the signals of several days on a virtual clock.
+func Test_Check_Simulate(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	from := time.Date(2023, 1, 31, 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 3)
+
+	// Options of a ticker which fires 4 times a day
+	opts := tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}
+
+	t.Run("as fast as possible", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// Run three days
+		report, err := gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 12, report.Firings)
+		require.Equal(t, 2, report.Rollovers)
+		require.Len(t, report.Signals, 15)
+
+		// The firings are the occurrences of the schedule, and every date ends with a wait for tomorrow
+		occurrences, err := gt.Occurrences(from, to)
+		require.NoError(t, err)
+		var fired []time.Time
+		for i := 0; i < len(report.Signals); i++ {
+			if i%5 == 4 {
+				require.Equal(t, tickerBase.SignalWaitForTomorrow, report.Signals[i].SignalStatus)
+				continue
+			}
+			require.Equal(t, tickerBase.SignalOnTime, report.Signals[i].SignalStatus)
+			fired = append(fired, time.Unix(report.Signals[i].TimeStamp, 0).In(location))
+		}
+		require.Equal(t, occurrences, fired)
+
+		// The clock of the ticker is put back
+		require.Equal(t, int64(0), gt.MockNano.Load())
+		require.Equal(t, StatusNewed, gt.Status.Load())
+		require.Equal(t, time.Now().In(location).Format(tickerBase.DefaultDateFormatStr), gt.NowDate)
+	})
+	t.Run("at a speed with the signals emitted", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// Receive the emitted signals
+		var received atomic.Int64
+		done := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-gt.SignalChan:
+					received.Add(1)
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		// Three days at 3 days per 300 milliseconds
+		start := time.Now()
+		report, err := gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{
+			Speed: float64(to.Sub(from) / (300 * time.Millisecond)),
+			Emit:  true,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Signals, 15)
+		require.Greater(t, time.Since(start), 200*time.Millisecond)
+		require.Eventually(t, func() bool {
+			return received.Load() == 15
+		}, time.Second, time.Millisecond)
+		close(done)
+	})
+	t.Run("interrupted or active", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// A cancelled run stops
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = gt.Simulate(ctx, from, to, tickerBase.SimulationOpts{})
+		require.Equal(t, tickerBase.ErrUserInterrupted, err)
+
+		// An attached ticker can not be simulated
+		engine := NewEngine()
+		require.NoError(t, engine.Attach(gt))
+		_, err = gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{})
+		require.Equal(t, tickerBase.ErrAlreadyActive, err)
+	})
+	t.Run("computer mode", func(t *testing.T) {
+		computer := opts
+		computer.Mode = tickerBase.CommputerMode
+		gt, err := New(computer, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// A point every hour for three days
+		report, err := gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 71, report.Firings)
+		require.Equal(t, from.Add(time.Hour).Unix(), report.Signals[0].TimeStamp)
+	})
+	t.Run("serial base is put back", func(t *testing.T) {
+		gt, err := New(opts, tickerBase.OffOpts{})
+		require.NoError(t, err)
+		gt.SerialBase = 10
+		gt.SerialHandler = func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+			*serialBase++
+			newSerial = *serialBase
+			return
+		}
+
+		// The run numbers its signals, but the live ticker goes on from its own serial base
+		report, err := gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), report.Signals[0].SerialNumber)
+		require.Equal(t, uint64(10), gt.SerialBase)
+	})
+	t.Run("days off", func(t *testing.T) {
+		// 2023-2-1 is a Wednesday
+		gt, err := New(opts, tickerBase.OffOpts{WednesdayOff: true})
+		require.NoError(t, err)
+
+		// The firings are the occurrences of the schedule, which skip the day off
+		report, err := gt.Simulate(context.Background(), from, to, tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		occurrences, err := gt.Occurrences(from, to)
+		require.NoError(t, err)
+		require.Len(t, occurrences, 8)
+		var fired []time.Time
+		for i := 0; i < len(report.Signals); i++ {
+			if report.Signals[i].SignalStatus == tickerBase.SignalOnTime {
+				fired = append(fired, time.Unix(report.Signals[i].TimeStamp, 0).In(location))
+			}
+		}
+		require.Equal(t, occurrences, fired)
+		require.Equal(t, 2, report.Rollovers)
+	})
+	t.Run("a quarter of points every five minutes", func(t *testing.T) {
+		gt, err := New(tickerBase.Opts{
+			BaseTime:  "0:0:0",
+			Location:  tickerBase.DefaultTimeZone,
+			Duration:  5 * time.Minute,
+			BeginTime: "0:0:0",
+			EndTime:   "23:59:59",
+		}, tickerBase.OffOpts{SaturdayOff: true, SundayOff: true})
+		require.NoError(t, err)
+
+		// From 2023-1-2, a Monday, for 13 weeks of 5 working days
+		monday := time.Date(2023, 1, 2, 0, 0, 0, 0, location)
+		start := time.Now()
+		report, err := gt.Simulate(context.Background(), monday, monday.AddDate(0, 0, 13*7), tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 13*5*288, report.Firings)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+	t.Run("fire both on an overlap", func(t *testing.T) {
+		// An element on the repeated point at 13:00 fires besides it
+		both := opts
+		both.BaseList = []string{"13:0:0"}
+		both.OverlapPolicy = tickerBase.OverlapFireBoth
+		gt, err := New(both, tickerBase.OffOpts{})
+		require.NoError(t, err)
+
+		// Run one day, where both points at 13:00 are on time
+		report, err := gt.Simulate(context.Background(), from, from.AddDate(0, 0, 1), tickerBase.SimulationOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Firings)
+		require.Equal(t, report.Signals[2].TimeStamp, report.Signals[3].TimeStamp)
+		require.Equal(t, tickerBase.SignalOnTime, report.Signals[3].SignalStatus)
+		require.Equal(t, tickerBase.SourceRepeat, report.Signals[2].Source)
+		require.Equal(t, tickerBase.SourceBaseList, report.Signals[3].Source)
+	})
+}
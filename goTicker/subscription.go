@@ -0,0 +1,172 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"sync/atomic"
+	"time"
+)
+
+// outlet is a channel which receives the signals of a ticker, together with its overflow policy and counters.
+type outlet struct {
+	signalChan     chan tickerBase.TickerSignal
+	overflowPolicy uint
+	overflowCount  *atomic.Uint64
+	droppedSignals *atomic.Uint64
+	doneChan       <-chan struct{}
+}
+
+// outlets lists the ticker channel and the channels of all subscriptions.
+// The caller must hold the lock.
+func (receive *GoTicker) outlets() (output []outlet) {
+	output = make([]outlet, 0, len(receive.Subscriptions)+1)
+
+	// The ticker channel may be set to nil when only subscriptions are used
+	if receive.SignalChan != nil {
+		output = append(output, outlet{
+			signalChan:     receive.SignalChan,
+			overflowPolicy: receive.Opts.OverflowPolicy,
+			overflowCount:  &receive.OverflowCount,
+			droppedSignals: &receive.DroppedSignals,
+		})
+	}
+
+	// Append the channel of every subscription
+	for i := 0; i < len(receive.Subscriptions); i++ {
+		output = append(output, outlet{
+			signalChan:     receive.Subscriptions[i].SignalChan,
+			overflowPolicy: receive.Subscriptions[i].OverflowPolicy,
+			overflowCount:  &receive.Subscriptions[i].OverflowCount,
+			droppedSignals: &receive.Subscriptions[i].DroppedSignals,
+			doneChan:       receive.Subscriptions[i].DoneChan,
+		})
+	}
+
+	// Return the output value
+	return
+}
+
+// send sends a signal to the outlet according to its overflow policy.
+// With OverflowBlock, it waits unless the context is done or the outlet is cancelled first,
+// and if a send timeout is set and nobody receives the signal in time, the signal is dropped and counted.
+// The other policies never wait, and count every signal they drop or merge.
+// An unbuffered channel has no oldest signal to take out, so a signal nobody is receiving right now is dropped.
+func (receive outlet) send(ctx context.Context, sendTimeout time.Duration, signal tickerBase.TickerSignal) (err error) {
+	switch receive.overflowPolicy {
+	case tickerBase.OverflowDropNewest:
+		receive.sendOrDrop(signal)
+		return
+	case tickerBase.OverflowDropOldest, tickerBase.OverflowCoalesce:
+		if cap(receive.signalChan) == 0 {
+			receive.sendOrDrop(signal)
+			return
+		}
+		for {
+			select {
+			case receive.signalChan <- signal:
+				return
+			case <-ctx.Done():
+				err = tickerBase.ErrUserInterrupted
+				return
+			default:
+			}
+			// The buffer is full, so take the oldest signal out to make room
+			select {
+			case oldest := <-receive.signalChan:
+				receive.overflowCount.Add(1)
+				if receive.overflowPolicy == tickerBase.OverflowCoalesce {
+					signal.CoalescedSignals += oldest.CoalescedSignals + 1
+				}
+			default:
+			}
+		}
+	}
+
+	// Only wait for the send timeout if it is set
+	var timeout <-chan time.Time
+	if sendTimeout > 0 {
+		timer := time.NewTimer(sendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case receive.signalChan <- signal:
+	case <-ctx.Done():
+		err = tickerBase.ErrUserInterrupted
+	case <-receive.doneChan:
+		// The subscription is cancelled, so nobody is going to receive the signal
+	case <-timeout:
+		// Nobody received the signal in time, so drop it
+		receive.droppedSignals.Add(1)
+	}
+
+	// Return err value
+	return
+}
+
+// sendOrDrop sends a signal to the outlet if it can take it right now, and counts it as dropped otherwise.
+func (receive outlet) sendOrDrop(signal tickerBase.TickerSignal) {
+	select {
+	case receive.signalChan <- signal:
+	default:
+		// The buffer is full, so drop the new signal
+		receive.overflowCount.Add(1)
+	}
+}
+
+// offer sends a signal to the outlet only if it can take it right now.
+func (receive outlet) offer(signal tickerBase.TickerSignal) {
+	select {
+	case receive.signalChan <- signal:
+	default:
+	}
+}
+
+// Subscribe creates a new subscription which receives every signal of the ticker with the same serial number.
+// Each subscription has its own buffer and overflow policy,
+// so a slow subscriber with a non-blocking policy does not stall the others.
+func (receive *GoTicker) Subscribe(bufferSize int, overflowPolicy uint) (subscription *tickerBase.Subscription, err error) {
+	// Check if the buffer is valid
+	err = tickerBase.CheckBuffer(bufferSize, overflowPolicy)
+	if err != nil {
+		return
+	}
+
+	// Create the subscription
+	subscription = &tickerBase.Subscription{
+		SignalChan:     make(chan tickerBase.TickerSignal, bufferSize),
+		OverflowPolicy: overflowPolicy,
+		DoneChan:       make(chan struct{}),
+	}
+
+	// Add the subscription under the lock
+	receive.Mu.Lock()
+	receive.Subscriptions = append(receive.Subscriptions, subscription)
+	receive.Mu.Unlock()
+
+	// Return the subscription and err values
+	return
+}
+
+// Unsubscribe cancels a subscription and closes its DoneChan.
+// The signal channel of the subscription is not closed, so receivers should also watch DoneChan.
+func (receive *GoTicker) Unsubscribe(subscription *tickerBase.Subscription) (err error) {
+	// Remove the subscription under the lock
+	receive.Mu.Lock()
+	defer receive.Mu.Unlock()
+	for i := 0; i < len(receive.Subscriptions); i++ {
+		if receive.Subscriptions[i] == subscription {
+			// Build a new slice, so outlets collected before stay untouched
+			subscriptions := make([]*tickerBase.Subscription, 0, len(receive.Subscriptions)-1)
+			subscriptions = append(subscriptions, receive.Subscriptions[:i]...)
+			receive.Subscriptions = append(subscriptions, receive.Subscriptions[i+1:]...)
+			close(subscription.DoneChan)
+			return
+		}
+	}
+
+	// The subscription does not belong to the ticker
+	err = tickerBase.ErrUnknownSubscription
+	return
+}
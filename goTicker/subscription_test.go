@@ -0,0 +1,115 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Subscribe checks that every subscription receives every signal with the same serial number,
+// and that a slow subscription does not stall the others.
+func Test_Check_Subscribe(t *testing.T) {
+	t.Run("invalid buffer", func(t *testing.T) {
+		gt := &GoTicker{}
+		_, err := gt.Subscribe(0, tickerBase.OverflowDropOldest)
+		require.Equal(t, tickerBase.ErrOverflowPolicyWithoutBuffer, err)
+	})
+	t.Run("fan out signals", func(t *testing.T) {
+		// Get the current time
+		now := time.Now()
+
+		// Create a new GoTicker and set its properties
+		gt := &GoTicker{
+			BaseStamp: now.Unix(),
+			BaseList: []int64{
+				now.Add(1 * time.Second).Unix(),
+				now.Add(2 * time.Second).Unix(),
+				now.Add(20 * time.Second).Unix(),
+			},
+			BeginStamp: now.Add(0 * time.Second).Unix(),
+			EndStamp:   now.Add(30 * time.Second).Unix(),
+			Opts: tickerBase.Opts{
+				Duration: time.Nanosecond,
+			},
+			// Set the serial handler function
+			SerialHandler: func(serialBase *uint64, timeStamp int64) (newSerial uint64) {
+				*serialBase++
+				newSerial = *serialBase
+				return
+			},
+		}
+		// Reload the location information for the ticker
+		err := gt.ReloadLocation()
+		require.NoError(t, err)
+		// Update the ticker's current date
+		err = gt.UpdateNowDateOrMock("")
+		require.NoError(t, err)
+
+		// Only use subscriptions, one is read and the other is never read
+		reader, err := gt.Subscribe(1, tickerBase.OverflowDropNewest)
+		require.NoError(t, err)
+		sleeper, err := gt.Subscribe(1, tickerBase.OverflowDropOldest)
+		require.NoError(t, err)
+
+		// Start a new goroutine to send signals from the ticker
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = gt.SendSignals(ctx)
+		}()
+
+		// The reader receives both points even though the sleeper never reads
+		for serial := uint64(1); serial <= 2; serial++ {
+			signalFromTicker := <-reader.SignalChan
+			require.Equal(t, tickerBase.SignalOnTime, signalFromTicker.SignalStatus)
+			require.Equal(t, serial, signalFromTicker.SerialNumber)
+		}
+
+		// The sleeper keeps the latest signal with the same serial number
+		require.Eventually(t, func() bool {
+			return sleeper.OverflowCount.Load() == 1
+		}, time.Second, 10*time.Millisecond)
+		signalFromTicker := <-sleeper.SignalChan
+		require.Equal(t, uint64(2), signalFromTicker.SerialNumber)
+		require.Equal(t, uint64(0), reader.OverflowCount.Load())
+	})
+}
+
+// Test_Check_Unsubscribe checks that a cancelled subscription is removed from the ticker and its DoneChan is closed.
+func Test_Check_Unsubscribe(t *testing.T) {
+	gt := &GoTicker{}
+
+	// Subscribe twice
+	first, err := gt.Subscribe(0, tickerBase.OverflowBlock)
+	require.NoError(t, err)
+	second, err := gt.Subscribe(0, tickerBase.OverflowBlock)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(gt.Subscriptions))
+
+	// Cancel the first subscription
+	err = gt.Unsubscribe(first)
+	require.NoError(t, err)
+	require.Equal(t, []*tickerBase.Subscription{second}, gt.Subscriptions)
+	_, open := <-first.DoneChan
+	require.Equal(t, false, open)
+
+	// Only the second subscription is left as an outlet
+	require.Equal(t, second.SignalChan, gt.outlets()[0].signalChan)
+
+	// A blocked send to a cancelled subscription returns at once
+	cancelled := outlet{
+		signalChan:     first.SignalChan,
+		overflowPolicy: first.OverflowPolicy,
+		overflowCount:  &first.OverflowCount,
+		droppedSignals: &first.DroppedSignals,
+		doneChan:       first.DoneChan,
+	}
+	err = cancelled.send(context.Background(), 0, tickerBase.TickerSignal{})
+	require.NoError(t, err)
+
+	// The first subscription can not be cancelled twice
+	err = gt.Unsubscribe(first)
+	require.Equal(t, tickerBase.ErrUnknownSubscription, err)
+}
@@ -0,0 +1,135 @@
+package goTicker
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"sync"
+	"time"
+)
+
+/*
+Ticker exposes the schedule of a GoTicker in the shape of *time.Ticker,
+so code written against time.Ticker can switch to BaseList and window aware schedules without rewriting its consumers.
+The scheduled time of every point, and of every manual trigger, is delivered on C.
+Like time.Ticker, C has a buffer of one and a tick is dropped if the receiver has not taken the previous one.
+*/
+type Ticker struct {
+	C        <-chan time.Time
+	GoTicker *GoTicker
+	c        chan time.Time
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewTicker creates a GoTicker with the options and starts delivering its points on C.
+func NewTicker(opts tickerBase.Opts, offOpts tickerBase.OffOpts) (ticker *Ticker, err error) {
+	// Create the GoTicker
+	var gt *GoTicker
+	gt, err = New(opts, offOpts)
+	if err != nil {
+		return
+	}
+
+	// Create the Ticker and start it
+	c := make(chan time.Time, 1)
+	ticker = &Ticker{C: c, GoTicker: gt, c: c}
+	ticker.start()
+
+	// Return the ticker and err values
+	return
+}
+
+// start runs SendSignals and forwards its signals to C until the ticker is stopped.
+// The caller must hold the lock or be the only one with access to the ticker.
+func (receive *Ticker) start() {
+	var ctx context.Context
+	ctx, receive.cancel = context.WithCancel(context.Background())
+	receive.done = make(chan struct{})
+
+	// Send signals until the context is cancelled
+	sent := make(chan struct{})
+	go func() {
+		_ = receive.GoTicker.SendSignals(ctx)
+		close(sent)
+	}()
+
+	// Forward the points to C
+	go func() {
+		defer close(receive.done)
+		for {
+			select {
+			case signal := <-receive.GoTicker.SignalChan:
+				if signal.SignalStatus != tickerBase.SignalOnTime &&
+					signal.SignalStatus != tickerBase.SignalDelay &&
+					signal.SignalStatus != tickerBase.SignalManual {
+					continue
+				}
+				// Deliver the time in the ticker's location
+				tick := time.Unix(signal.TimeStamp, 0)
+				receive.GoTicker.Mu.Lock()
+				if receive.GoTicker.BaseLocation != nil {
+					tick = tick.In(receive.GoTicker.BaseLocation)
+				}
+				receive.GoTicker.Mu.Unlock()
+				// Drop the tick if the receiver has not taken the previous one
+				select {
+				case receive.c <- tick:
+				default:
+				}
+			case <-sent:
+				return
+			}
+		}
+	}()
+}
+
+// Stop turns off the ticker. After Stop, no more ticks will be sent, and C is not closed.
+func (receive *Ticker) Stop() {
+	receive.mu.Lock()
+	defer receive.mu.Unlock()
+
+	// Cancel SendSignals and wait for the forwarding to finish
+	if receive.cancel != nil {
+		receive.cancel()
+		<-receive.done
+		receive.cancel = nil
+	}
+}
+
+/*
+Reset changes the repeat Duration of the schedule to d and, like time.Ticker, restarts a stopped ticker.
+The new points are still anchored at the BaseTime of the options.
+Like time.Ticker panics on an interval it can not use, Reset panics if d is not positive
+or the options are rejected with it, such as a BaseList element on a new repeated point under OverlapError,
+and the ticker then keeps its duration and is not restarted.
+*/
+func (receive *Ticker) Reset(d time.Duration) {
+	// Follow time.Ticker, which does not accept a non-positive interval
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	receive.mu.Lock()
+	defer receive.mu.Unlock()
+
+	// Update the duration, the running SendSignals recalculates its wait list
+	receive.GoTicker.Mu.Lock()
+	opts := receive.GoTicker.Opts
+	offOpts := receive.GoTicker.OffOpts
+	receive.GoTicker.Mu.Unlock()
+	opts.Duration = d
+	opts.BaseList = append([]string(nil), opts.BaseList...)
+	if err := receive.GoTicker.UpdateOpts(opts, offOpts); err != nil {
+		panic("invalid interval for Ticker.Reset: " + err.Error())
+	}
+
+	// Restart a stopped ticker, which calculates its wait list from the new options anyway
+	if receive.cancel == nil {
+		select {
+		case <-receive.GoTicker.ReloadChan:
+		default:
+		}
+		receive.start()
+	}
+}
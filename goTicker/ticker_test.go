@@ -0,0 +1,95 @@
+package goTicker
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Test_Check_Ticker checks that the Ticker delivers the scheduled times on C, and follows Reset and Stop like time.Ticker.
+func Test_Check_Ticker(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+
+	// Get the current time
+	now := time.Now().In(location)
+
+	// Create a ticker repeating every second
+	opts := tickerBase.Opts{
+		BaseTime:  now.Format(tickerBase.DefaultDateTimeFormatStr),
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Second,
+		BeginTime: now.Add(-1 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr),
+		EndTime:   now.Add(30 * time.Second).Format(tickerBase.DefaultDateTimeFormatStr),
+	}
+	ticker, err := NewTicker(opts, tickerBase.OffOpts{})
+	require.NoError(t, err)
+
+	t.Run("ticks are the scheduled times", func(t *testing.T) {
+		previous := <-ticker.C
+		for i := 0; i < 2; i++ {
+			tick := <-ticker.C
+			require.Equal(t, time.Second, tick.Sub(previous))
+			require.Equal(t, 0, tick.Nanosecond())
+			previous = tick
+		}
+	})
+	t.Run("reset changes the interval", func(t *testing.T) {
+		ticker.Reset(2 * time.Second)
+		// Skip the tick which may have been buffered before the reset
+		<-ticker.C
+		previous := <-ticker.C
+		tick := <-ticker.C
+		require.Equal(t, 2*time.Second, tick.Sub(previous))
+		require.Panics(t, func() { ticker.Reset(0) })
+	})
+	t.Run("stop turns off the ticker", func(t *testing.T) {
+		ticker.Stop()
+		// Drain the buffer, after which no more ticks arrive
+		select {
+		case <-ticker.C:
+		default:
+		}
+		select {
+		case <-ticker.C:
+			t.Fatal("a tick arrived after Stop")
+		case <-time.After(2500 * time.Millisecond):
+		}
+		// Stopping twice is harmless
+		ticker.Stop()
+	})
+	t.Run("reset restarts a stopped ticker", func(t *testing.T) {
+		ticker.Reset(time.Second)
+		previous := <-ticker.C
+		tick := <-ticker.C
+		require.Equal(t, time.Second, tick.Sub(previous))
+		ticker.Stop()
+	})
+}
+
+// Test_Check_Ticker_Reset_Overlap checks that Reset panics and keeps the duration when the new duration is rejected.
+func Test_Check_Ticker_Reset_Overlap(t *testing.T) {
+	// Create a ticker whose element is 3 seconds after a repeated point
+	ticker, err := NewTicker(tickerBase.Opts{
+		BaseTime:      "0:0:0",
+		Location:      tickerBase.DefaultTimeZone,
+		Duration:      7 * time.Second,
+		BaseList:      []string{"0:0:10"},
+		BeginTime:     "0:0:0",
+		EndTime:       "23:59:59",
+		OverlapPolicy: tickerBase.OverlapError,
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	ticker.Stop()
+
+	// A duration of 5 seconds puts the element on a repeated point
+	require.PanicsWithValue(t, "invalid interval for Ticker.Reset: invalid options: BaseList[0] \"0:0:10\": base list overlaps", func() { ticker.Reset(5 * time.Second) })
+	require.Equal(t, 7*time.Second, ticker.GoTicker.Snapshot().Opts.Duration)
+
+	// The stopped ticker is not restarted on the old options
+	ticker.mu.Lock()
+	require.Nil(t, ticker.cancel)
+	ticker.mu.Unlock()
+}
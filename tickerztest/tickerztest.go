@@ -0,0 +1,256 @@
+/*
+Package tickerztest helps to test code which consumes a GoTicker without waiting for the real points.
+A Clock controls the mocked time of the ticker, a Recorder runs SendSignals and captures every signal,
+and the assertions of the Recorder check which points have fired.
+*/
+package tickerztest
+
+import (
+	"context"
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/panhongrainbow/tickerz/goTicker"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// settleTimeout is how long the clock waits for SendSignals to take a point, which only runs out if nobody receives the signals.
+const settleTimeout = 5 * time.Second
+
+// Clock is a controllable clock of a ticker, which moves its mocked time.
+type Clock struct {
+	t        testing.TB
+	ticker   *goTicker.GoTicker
+	location *time.Location // the location the clock tells the time in
+}
+
+// NewClock pins the time of the ticker to now, and puts the ticker back on the system clock when the test ends.
+// The clock tells the time in the location of now.
+func NewClock(t testing.TB, ticker *goTicker.GoTicker, now time.Time) (clock *Clock) {
+	t.Helper()
+	if err := ticker.MockTime(now); err != nil {
+		t.Fatalf("tickerztest: mock the time of the ticker: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = ticker.UnmockTime()
+	})
+	clock = &Clock{t: t, ticker: ticker, location: now.Location()}
+
+	// Return the clock value
+	return
+}
+
+// Now returns the current time of the clock in its location.
+func (receive *Clock) Now() time.Time {
+	return receive.ticker.Now().In(receive.location)
+}
+
+// Advance moves the clock forward by d, like AdvanceTo.
+func (receive *Clock) Advance(d time.Duration) {
+	receive.t.Helper()
+	receive.AdvanceTo(receive.Now().Add(d))
+}
+
+/*
+AdvanceTo moves the clock forward to the given time.
+It stops at every point a running SendSignals waits for on the way, and waits for SendSignals to take it,
+so every point fires on time and in order, and no point is left to fire when it returns.
+*/
+func (receive *Clock) AdvanceTo(target time.Time) {
+	receive.t.Helper()
+	for {
+		// Find the next point SendSignals waits for, which must not be after the target
+		deadline := receive.settle()
+		if deadline == 0 || deadline > target.UnixNano() {
+			break
+		}
+
+		// Step to the point and wait for SendSignals to take it
+		receive.advance(receive.until(time.Unix(0, deadline)))
+		receive.waitFor(func() bool {
+			return receive.ticker.MockWait.Load() != deadline
+		})
+	}
+
+	// Move the rest of the way
+	receive.advance(receive.until(target))
+}
+
+// advance moves the mocked time of the ticker forward by d, and fails the test if it can not.
+func (receive *Clock) advance(d time.Duration) {
+	receive.t.Helper()
+	if err := receive.ticker.AdvanceMockTime(d); err != nil {
+		receive.t.Fatalf("tickerztest: advance the clock: %v", err)
+	}
+}
+
+// until returns how far the clock is from the given time, or 0 if it is there already.
+func (receive *Clock) until(target time.Time) (d time.Duration) {
+	d = target.Sub(receive.Now())
+	if d < 0 {
+		d = 0
+	}
+
+	// Return the d value
+	return
+}
+
+// settle waits until SendSignals waits for a point, and returns its Unix nanoseconds, or 0 if SendSignals is not running.
+func (receive *Clock) settle() (deadline int64) {
+	receive.t.Helper()
+	receive.waitFor(func() bool {
+		deadline = receive.ticker.MockWait.Load()
+		return deadline != 0 || atomic.LoadUint32(&receive.ticker.Active32) == 0
+	})
+
+	// Return the deadline value
+	return
+}
+
+// waitFor waits until the condition is met, and fails the test if it is not met within settleTimeout.
+func (receive *Clock) waitFor(condition func() bool) {
+	receive.t.Helper()
+	if !waitFor(condition) {
+		receive.t.Fatalf("tickerztest: SendSignals did not settle within %s, is anything receiving its signals?", settleTimeout)
+	}
+}
+
+// waitFor waits until the condition is met, and reports whether it is met within settleTimeout.
+func waitFor(condition func() bool) (met bool) {
+	expire := time.Now().Add(settleTimeout)
+	for !condition() {
+		if time.Now().After(expire) {
+			return
+		}
+		time.Sleep(50 * time.Microsecond)
+	}
+	met = true
+
+	// Return the met value
+	return
+}
+
+// Recorder runs SendSignals for a ticker and captures every signal of the ticker channel.
+type Recorder struct {
+	t       testing.TB
+	mu      sync.Mutex
+	signals []tickerBase.TickerSignal
+	flush   chan chan struct{} // asks the receiving goroutine to catch up
+	stop    chan struct{}      // closed when the test ends
+}
+
+// NewRecorder starts SendSignals for the ticker and records its signals until the test ends.
+// The recorder takes the ticker channel, so nothing else should receive from it.
+func NewRecorder(t testing.TB, ticker *goTicker.GoTicker) (recorder *Recorder) {
+	t.Helper()
+	recorder = &Recorder{
+		t:     t,
+		flush: make(chan chan struct{}),
+		stop:  make(chan struct{}),
+	}
+
+	// Start SendSignals and wait until it runs, so a clock sees it
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ticker.SendSignals(ctx)
+	}()
+	running := waitFor(func() bool {
+		return atomic.LoadUint32(&ticker.Active32) == 1
+	})
+	if !running {
+		cancel()
+		t.Fatalf("tickerztest: SendSignals did not start within %s: %v", settleTimeout, <-errChan)
+	}
+
+	// Receive the signals
+	go recorder.receive(ticker.SignalChan)
+
+	// Stop SendSignals and then the receiving goroutine when the test ends
+	t.Cleanup(func() {
+		cancel()
+		<-errChan
+		close(recorder.stop)
+	})
+
+	// Return the recorder value
+	return
+}
+
+// receive records the signals of the channel until the test ends.
+func (receive *Recorder) receive(signalChan chan tickerBase.TickerSignal) {
+	for {
+		select {
+		case signal := <-signalChan:
+			receive.record(signal)
+		case done := <-receive.flush:
+			// Take the signals left in the buffer before answering
+			for caughtUp := false; !caughtUp; {
+				select {
+				case signal := <-signalChan:
+					receive.record(signal)
+				default:
+					caughtUp = true
+				}
+			}
+			close(done)
+		case <-receive.stop:
+			return
+		}
+	}
+}
+
+// record adds a signal to the recorded ones.
+func (receive *Recorder) record(signal tickerBase.TickerSignal) {
+	receive.mu.Lock()
+	receive.signals = append(receive.signals, signal)
+	receive.mu.Unlock()
+}
+
+// Signals returns every signal recorded so far, including every signal sent before the call.
+func (receive *Recorder) Signals() (output []tickerBase.TickerSignal) {
+	// Let the receiving goroutine catch up, unless the test has ended
+	done := make(chan struct{})
+	select {
+	case receive.flush <- done:
+		<-done
+	case <-receive.stop:
+	}
+
+	// Copy the signals
+	receive.mu.Lock()
+	output = append(output, receive.signals...)
+	receive.mu.Unlock()
+
+	// Return the output value
+	return
+}
+
+// ExpectFire asserts that a signal with the status has been recorded for the point at.
+func (receive *Recorder) ExpectFire(at time.Time, status uint) {
+	receive.t.Helper()
+	signals := receive.Signals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].TimeStamp == at.Unix() && signals[i].SignalStatus == status {
+			return
+		}
+	}
+	receive.t.Errorf("tickerztest: missing fire: no signal with status %d for %s in %d recorded signals", status, at, len(signals))
+}
+
+// ExpectNoFire asserts that no on-time or delay signal has been recorded for a point from from (inclusive) to to (exclusive).
+func (receive *Recorder) ExpectNoFire(from, to time.Time) {
+	receive.t.Helper()
+	signals := receive.Signals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].SignalStatus != tickerBase.SignalOnTime && signals[i].SignalStatus != tickerBase.SignalDelay {
+			continue
+		}
+		if signals[i].TimeStamp >= from.Unix() && signals[i].TimeStamp < to.Unix() {
+			receive.t.Errorf("tickerztest: unexpected fire: signal with status %d for %s, which is from %s to %s", signals[i].SignalStatus,
+				time.Unix(signals[i].TimeStamp, 0).In(from.Location()), from, to)
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tickerztest
+
+import (
+	tickerBase "github.com/panhongrainbow/tickerz/base"
+	"github.com/panhongrainbow/tickerz/goTicker"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// failT is a testing.TB which records a failure instead of failing the test.
+type failT struct {
+	testing.TB
+	failed bool
+}
+
+func (receive *failT) Errorf(format string, args ...any) { receive.failed = true }
+func (receive *failT) Fatalf(format string, args ...any) { receive.failed = true }
+
+// Test_Check_Clock_Recorder checks that the clock fires the points of a ticker in order without real waits,
+// and that the assertions of the recorder find them.
+func Test_Check_Clock_Recorder(t *testing.T) {
+	// Load the location of the ticker
+	location, err := time.LoadLocation(tickerBase.DefaultTimeZone)
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2023, 1, day, hour, minute, 0, 0, location)
+	}
+
+	// Create a ticker which fires 4 times a day
+	gt, err := goTicker.New(tickerBase.Opts{
+		BaseTime:  "12:0:0",
+		Location:  tickerBase.DefaultTimeZone,
+		Duration:  time.Hour,
+		BaseList:  []string{"12:30:0"},
+		BeginTime: "11:0:0",
+		EndTime:   "14:0:0",
+	}, tickerBase.OffOpts{})
+	require.NoError(t, err)
+	clock := NewClock(t, gt, at(30, 10, 0))
+	recorder := NewRecorder(t, gt)
+
+	// Nothing fires before the window
+	start := time.Now()
+	clock.Advance(50 * time.Minute)
+	recorder.ExpectNoFire(at(30, 0, 0), at(31, 0, 0))
+	require.WithinDuration(t, at(30, 10, 50), clock.Now(), 0)
+	require.Equal(t, location, clock.Now().Location())
+
+	// The points of the day fire in order
+	clock.AdvanceTo(at(30, 12, 30))
+	recorder.ExpectFire(at(30, 11, 0), tickerBase.SignalOnTime)
+	recorder.ExpectFire(at(30, 12, 0), tickerBase.SignalOnTime)
+	recorder.ExpectFire(at(30, 12, 30), tickerBase.SignalOnTime)
+	recorder.ExpectNoFire(at(30, 12, 31), at(31, 0, 0))
+
+	// The ticker waits for tomorrow and fires the points of the next day
+	clock.Advance(24 * time.Hour)
+	signals := recorder.Signals()
+	require.Len(t, signals, 8)
+	require.Equal(t, tickerBase.SignalWaitForTomorrow, signals[4].SignalStatus)
+	recorder.ExpectFire(at(31, 12, 0), tickerBase.SignalOnTime)
+	recorder.ExpectNoFire(at(31, 12, 31), at(31, 23, 0))
+	require.Less(t, time.Since(start), time.Second)
+
+	// The assertions fail for points which did not fire as expected
+	failed := &failT{TB: t}
+	(&Recorder{t: failed, stop: recorder.stop, flush: recorder.flush, signals: signals}).ExpectFire(at(30, 13, 30), tickerBase.SignalOnTime)
+	require.True(t, failed.failed)
+	failed = &failT{TB: t}
+	(&Recorder{t: failed, stop: recorder.stop, flush: recorder.flush, signals: signals}).ExpectNoFire(at(30, 13, 0), at(30, 14, 0))
+	require.True(t, failed.failed)
+}